@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/nilpntr/gluster-exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"os"
+	"time"
+)
+
+func init() {
+	recordCmd.Flags().StringP("output", "o", "", "Path of the bundle, defaults to gluster-exporter-record-<timestamp>.tar.gz")
+	recordCmd.Flags().Bool("anonymize", false, "Replace hostnames, IPs, UUIDs and paths consistently in the recorded output")
+	rootCmd.AddCommand(recordCmd)
+}
+
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record the output of all gluster commands the enabled collectors use into a support bundle",
+	Long: "Record runs every gluster command the enabled collectors use once and stores the raw xml, exit codes, " +
+		"stderr and 'gluster --version' in a tarball. The bundle can be replayed with --gluster.fixtures-dir.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			output = fmt.Sprintf("gluster-exporter-record-%v.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+		}
+		anonymize, _ := cmd.Flags().GetBool("anonymize")
+
+		runner, err := newGlusterRunner()
+		if err != nil {
+			return err
+		}
+
+		recorder := gluster.NewRecordingRunner(runner)
+		glusterClient, err := newGlusterClient(recorder)
+		if err != nil {
+			return err
+		}
+
+		// the commands have to run now instead of in a background loop
+		viper.Set("collector_interval", 0)
+		metricsClient, err := metrics.New(glusterClient)
+		if err != nil {
+			return err
+		}
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(metricsClient)
+		if _, err := registry.Gather(); err != nil {
+			zap.L().Sugar().Warnf("collected inconsistent metrics: %v", err)
+		}
+
+		version, err := runner.Run(cmd.Context(), "--version")
+		if err != nil {
+			zap.L().Sugar().Errorf("couldn't get gluster version: %v", err)
+		}
+
+		var anonymizer *gluster.Anonymizer
+		if anonymize {
+			anonymizer = gluster.NewAnonymizer()
+		}
+
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		calls := recorder.Calls()
+		if err := gluster.WriteBundle(f, calls, string(version.Stdout), anonymizer); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Recorded %v gluster commands to %v\n", len(calls), output)
+		return nil
+	},
+}
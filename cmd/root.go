@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
 	"github.com/nilpntr/gluster-exporter/internal/handlers"
 	"github.com/nilpntr/gluster-exporter/internal/metrics"
+	"github.com/nilpntr/gluster-exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -13,17 +15,31 @@ import (
 	"go.uber.org/zap/zapcore"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gluster-exporter",
 	Short: "Gluster Exporter is an exporter for gluster to prometheus",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		metricsClient, err := metrics.New()
+		runner, err := newGlusterRunner()
 		if err != nil {
 			return err
 		}
 
+		glusterClient, err := newGlusterClient(runner)
+		if err != nil {
+			return err
+		}
+
+		metricsClient, err := metrics.New(glusterClient)
+		if err != nil {
+			return err
+		}
+
+		go metricsClient.Run(cmd.Context())
+
 		registry := prometheus.NewRegistry()
 		registry.MustRegister(metricsClient, version.NewCollector("gluster_exporter"))
 
@@ -31,6 +47,16 @@ var rootCmd = &cobra.Command{
 
 		mux.Handle(viper.GetString("web_metrics_path"), promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
 		mux.HandleFunc("/healthz", handlers.Healthz)
+		volumes := strings.Split(viper.GetString("gluster_volumes"), ",")
+		if viper.GetBool("web_top_endpoint") {
+			mux.HandleFunc("/top", handlers.Top(glusterClient, volumes, viper.GetInt("collector_top_list_cnt")))
+		}
+		if viper.GetBool("web_split_brain_endpoint") {
+			mux.HandleFunc("/heal/split-brain", handlers.SplitBrain(glusterClient, volumes))
+		}
+		if viper.GetBool("web_bitrot_endpoint") {
+			mux.HandleFunc("/bitrot/corrupted", handlers.Bitrot(glusterClient, volumes))
+		}
 
 		zap.L().Sugar().Infof("Starting exporter on: %v", viper.GetInt("web_listen_address"))
 
@@ -44,27 +70,138 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	cobra.OnInitialize(initConfig, initLogger)
-	rootCmd.Flags().String("log.level", "info", "Which log level to use, allowed levels: [info, error, debug]")
+	rootCmd.PersistentFlags().String("log.level", "info", "Which log level to use, allowed levels: [info, error, debug]")
 	rootCmd.Flags().String("web.listen-address", ":9106", "Address to listen on for web interface")
 	rootCmd.Flags().String("web.metrics-path", "/metrics", "Path under which to expose metrics")
-	rootCmd.Flags().String("gluster.volumes", "_all", "Comma separated volume names: vol1,vol2,vol3. Default is '_all' to scrape all metrics")
-	rootCmd.Flags().String("gluster.binary", "/usr/sbin/gluster", "Path to the gluster binary")
-	rootCmd.Flags().Bool("profile", false, "Enable gluster profiling reports")
-	rootCmd.Flags().Bool("quota", false, "Enable gluster quota reports")
+	rootCmd.Flags().Bool("web.top-endpoint", false, "Expose the busiest files of the configured volumes as JSON under /top, every request runs 'gluster volume top'")
+	rootCmd.Flags().Bool("web.split-brain-endpoint", false, "Expose the paths and GFIDs in split-brain as JSON under /heal/split-brain")
+	rootCmd.Flags().Bool("web.bitrot-endpoint", false, "Expose the GFIDs of the objects the scrubber found corrupted as JSON under /bitrot/corrupted")
+	rootCmd.PersistentFlags().String("gluster.volumes", gluster.AllVolumes, "Comma separated volume names: vol1,vol2,vol3. Default is '_all' to scrape all metrics")
+	rootCmd.PersistentFlags().String("gluster.binary", "/usr/sbin/gluster", "Path to the gluster binary")
+	rootCmd.PersistentFlags().String("gluster.command-prefix", "", "Wrapper command the gluster binary is run with, e.g. 'sudo -n' or 'docker exec glusterd'")
+	rootCmd.PersistentFlags().String("gluster.remote-host", "", "Query the glusterd on this host instead of the local one, node local checks like mounts are disabled then")
+	rootCmd.PersistentFlags().String("gluster.glusterd-sock", "", "Path of the glusterd socket, defaults to the one the gluster CLI uses")
+	rootCmd.PersistentFlags().String("gluster.fixtures-dir", "", "Answer gluster commands from xml files in this directory or a recorded bundle instead of running the gluster binary, e.g. ./test")
+	rootCmd.PersistentFlags().Duration("gluster.timeout", gluster.DefaultTimeout, "Timeout for a single gluster command, 0 disables it")
+	rootCmd.PersistentFlags().StringToString("gluster.command-timeouts", nil, "Per command timeouts overriding --gluster.timeout: 'volume heal info=2m,volume status=1m'")
+	rootCmd.PersistentFlags().String("gluster.timezone", "", "Time zone of the gluster nodes, e.g. Europe/Berlin, used for the timestamps the CLI prints in local time. Defaults to the time zone of the exporter")
+	rootCmd.PersistentFlags().Int("gluster.retries", gluster.DefaultRetries, "How often a gluster command is retried when glusterd is locked by another transaction")
+	rootCmd.PersistentFlags().Duration("gluster.retry-backoff", gluster.DefaultRetryBackoff, "Base delay of the jittered exponential backoff between retries")
+	rootCmd.PersistentFlags().Duration("collector.interval", 0, "Collect in the background at this interval and serve scrapes from the latest snapshot, 0 collects on every scrape")
+	rootCmd.PersistentFlags().Int("collector.concurrency", 1, "How many volumes are collected in parallel")
+	rootCmd.PersistentFlags().Bool("profile", false, "Enable gluster profiling reports")
+	rootCmd.PersistentFlags().Bool("quota", false, "Enable gluster quota reports")
+	rootCmd.PersistentFlags().Bool("collector.clients", false, "Enable client connection metrics from 'gluster volume status <volume> clients'")
+	rootCmd.PersistentFlags().Bool("collector.clients.hostnames", false, "Label the client byte gauges with the client host instead of summing them per brick")
+	rootCmd.PersistentFlags().Bool("collector.mem", false, "Enable brick process memory metrics from 'gluster volume status <volume> mem'")
+	rootCmd.PersistentFlags().Bool("collector.fd", false, "Enable open fd metrics from 'gluster volume status <volume> fd'")
+	rootCmd.PersistentFlags().Bool("collector.inode", false, "Enable inode table metrics from 'gluster volume status <volume> inode'")
+	rootCmd.PersistentFlags().Bool("collector.callpool", false, "Enable pending call stack metrics from 'gluster volume status <volume> callpool'")
+	rootCmd.PersistentFlags().Bool("collector.heal-statistics", false, "Enable self-heal daemon crawl metrics from 'gluster volume heal <volume> statistics' and 'statistics heal-count'")
+	rootCmd.PersistentFlags().Bool("collector.georep", false, "Enable geo-replication worker metrics from 'gluster volume geo-replication status detail'")
+	rootCmd.PersistentFlags().String("collector.volume-options", strings.Join(gluster.DefaultVolumeOptions, ","), "Comma separated volume options exported as gluster_volume_option_info when set")
+	rootCmd.PersistentFlags().String("collector.volume-options.policy", "", "Yaml file with the desired volume options, differences are exported as gluster_volume_option_drift")
+	rootCmd.PersistentFlags().Bool("collector.snapshots", false, "Enable snapshot count, limit and age metrics from 'gluster snapshot info' and 'gluster snapshot config'")
+	rootCmd.PersistentFlags().Bool("collector.bitrot", false, "Enable bitrot scrub metrics from 'gluster volume bitrot <volume> scrub status'")
+	rootCmd.PersistentFlags().Bool("collector.rebalance", false, "Enable rebalance and remove-brick progress metrics from 'gluster volume status <volume> tasks' and the task status commands")
+	rootCmd.PersistentFlags().Bool("collector.top", false, "Enable busiest file metrics from 'gluster volume top <volume> <op> list-cnt <n>'")
+	rootCmd.PersistentFlags().String("collector.top.ops", strings.Join(gluster.TopOps, ","), "Comma separated top operations to collect")
+	rootCmd.PersistentFlags().Bool("collector.top.perf", false, "Additionally collect read-perf and write-perf throughput per file")
+	rootCmd.PersistentFlags().Int("collector.top.list-cnt", 10, fmt.Sprintf("How many files per brick and operation are collected, at most %v", gluster.MaxTopListCount))
 }
 
 func initConfig() {
-	_ = viper.BindPFlag("log_level", rootCmd.Flags().Lookup("log.level"))
+	_ = viper.BindPFlag("log_level", rootCmd.PersistentFlags().Lookup("log.level"))
 	_ = viper.BindPFlag("web_listen_address", rootCmd.Flags().Lookup("web.listen-address"))
 	_ = viper.BindPFlag("web_metrics_path", rootCmd.Flags().Lookup("web.metrics-path"))
-	_ = viper.BindPFlag("gluster_volumes", rootCmd.Flags().Lookup("gluster.volumes"))
-	_ = viper.BindPFlag("gluster_binary", rootCmd.Flags().Lookup("gluster.binary"))
-	_ = viper.BindPFlag("profile", rootCmd.Flags().Lookup("profile"))
-	_ = viper.BindPFlag("quota", rootCmd.Flags().Lookup("quota"))
+	_ = viper.BindPFlag("web_top_endpoint", rootCmd.Flags().Lookup("web.top-endpoint"))
+	_ = viper.BindPFlag("web_split_brain_endpoint", rootCmd.Flags().Lookup("web.split-brain-endpoint"))
+	_ = viper.BindPFlag("web_bitrot_endpoint", rootCmd.Flags().Lookup("web.bitrot-endpoint"))
+	_ = viper.BindPFlag("gluster_volumes", rootCmd.PersistentFlags().Lookup("gluster.volumes"))
+	_ = viper.BindPFlag("gluster_binary", rootCmd.PersistentFlags().Lookup("gluster.binary"))
+	_ = viper.BindPFlag("gluster_command_prefix", rootCmd.PersistentFlags().Lookup("gluster.command-prefix"))
+	_ = viper.BindPFlag("gluster_remote_host", rootCmd.PersistentFlags().Lookup("gluster.remote-host"))
+	_ = viper.BindPFlag("gluster_glusterd_sock", rootCmd.PersistentFlags().Lookup("gluster.glusterd-sock"))
+	_ = viper.BindPFlag("gluster_fixtures_dir", rootCmd.PersistentFlags().Lookup("gluster.fixtures-dir"))
+	_ = viper.BindPFlag("gluster_timeout", rootCmd.PersistentFlags().Lookup("gluster.timeout"))
+	_ = viper.BindPFlag("gluster_command_timeouts", rootCmd.PersistentFlags().Lookup("gluster.command-timeouts"))
+	_ = viper.BindPFlag("gluster_timezone", rootCmd.PersistentFlags().Lookup("gluster.timezone"))
+	_ = viper.BindPFlag("gluster_retries", rootCmd.PersistentFlags().Lookup("gluster.retries"))
+	_ = viper.BindPFlag("gluster_retry_backoff", rootCmd.PersistentFlags().Lookup("gluster.retry-backoff"))
+	_ = viper.BindPFlag("collector_interval", rootCmd.PersistentFlags().Lookup("collector.interval"))
+	_ = viper.BindPFlag("collector_concurrency", rootCmd.PersistentFlags().Lookup("collector.concurrency"))
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	_ = viper.BindPFlag("quota", rootCmd.PersistentFlags().Lookup("quota"))
+	_ = viper.BindPFlag("collector_clients", rootCmd.PersistentFlags().Lookup("collector.clients"))
+	_ = viper.BindPFlag("collector_clients_hostnames", rootCmd.PersistentFlags().Lookup("collector.clients.hostnames"))
+	_ = viper.BindPFlag("collector_mem", rootCmd.PersistentFlags().Lookup("collector.mem"))
+	_ = viper.BindPFlag("collector_fd", rootCmd.PersistentFlags().Lookup("collector.fd"))
+	_ = viper.BindPFlag("collector_inode", rootCmd.PersistentFlags().Lookup("collector.inode"))
+	_ = viper.BindPFlag("collector_callpool", rootCmd.PersistentFlags().Lookup("collector.callpool"))
+	_ = viper.BindPFlag("collector_heal_statistics", rootCmd.PersistentFlags().Lookup("collector.heal-statistics"))
+	_ = viper.BindPFlag("collector_georep", rootCmd.PersistentFlags().Lookup("collector.georep"))
+	_ = viper.BindPFlag("collector_volume_options", rootCmd.PersistentFlags().Lookup("collector.volume-options"))
+	_ = viper.BindPFlag("collector_volume_options_policy", rootCmd.PersistentFlags().Lookup("collector.volume-options.policy"))
+	_ = viper.BindPFlag("collector_snapshots", rootCmd.PersistentFlags().Lookup("collector.snapshots"))
+	_ = viper.BindPFlag("collector_bitrot", rootCmd.PersistentFlags().Lookup("collector.bitrot"))
+	_ = viper.BindPFlag("collector_rebalance", rootCmd.PersistentFlags().Lookup("collector.rebalance"))
+	_ = viper.BindPFlag("collector_top", rootCmd.PersistentFlags().Lookup("collector.top"))
+	_ = viper.BindPFlag("collector_top_ops", rootCmd.PersistentFlags().Lookup("collector.top.ops"))
+	_ = viper.BindPFlag("collector_top_perf", rootCmd.PersistentFlags().Lookup("collector.top.perf"))
+	_ = viper.BindPFlag("collector_top_list_cnt", rootCmd.PersistentFlags().Lookup("collector.top.list-cnt"))
 
 	viper.AutomaticEnv()
 }
 
+// newGlusterRunner returns the runner executing gluster commands, either the
+// gluster binary or the configured fixtures
+func newGlusterRunner() (gluster.Runner, error) {
+	dir := viper.GetString("gluster_fixtures_dir")
+	if dir == "" {
+		runner := gluster.NewExecRunner(viper.GetString("gluster_binary"))
+		prefix, err := utils.SplitArgs(viper.GetString("gluster_command_prefix"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gluster command prefix: %w", err)
+		}
+		runner.Prefix = prefix
+		runner.RemoteHost = viper.GetString("gluster_remote_host")
+		runner.GlusterdSocket = viper.GetString("gluster_glusterd_sock")
+		if runner.RemoteHost != "" {
+			zap.L().Sugar().Infof("Querying remote glusterd on %v, node local checks are disabled", runner.RemoteHost)
+		}
+		return runner, nil
+	}
+
+	if !utils.FileExists(dir) {
+		return nil, fmt.Errorf("gluster fixtures dir %v not found", dir)
+	}
+	zap.L().Sugar().Infof("Replaying gluster commands from fixtures in %v", dir)
+	return gluster.OpenFixtures(dir)
+}
+
+// newGlusterClient returns a client for runner configured by the gluster flags
+func newGlusterClient(runner gluster.Runner) (*gluster.Client, error) {
+	client := gluster.NewClient(runner)
+	client.Timeout = viper.GetDuration("gluster_timeout")
+	client.Retries = viper.GetInt("gluster_retries")
+	client.RetryBackoff = viper.GetDuration("gluster_retry_backoff")
+	if timezone := viper.GetString("gluster_timezone"); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gluster timezone: %w", err)
+		}
+		client.Location = location
+	}
+	for command, value := range viper.GetStringMapString("gluster_command_timeouts") {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for gluster command %q: %w", command, err)
+		}
+		client.Timeouts[command] = timeout
+	}
+	return client, nil
+}
+
 func initLogger() {
 	var level zapcore.Level
 	switch viper.GetString("log_level") {
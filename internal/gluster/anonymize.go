@@ -0,0 +1,183 @@
+package gluster
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	uuidPattern     = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{8,12}`)
+	ipv4Pattern     = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	hostnamePattern = regexp.MustCompile(`<(?:hostname|hostName|host|nodeName|slave_node|primary_node|secondary_node)>([^<]+)</`)
+	pathPattern     = regexp.MustCompile(`<(?:path|mntPoint|brick_path|file)(?:\s[^>]*)?>(/[^<]+)</`)
+
+	// host:/path of bricks, but neither the scheme of URLs like ssh://host
+	// nor the end of a longer token
+	hostPathPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9._/@:-])([A-Za-z0-9][A-Za-z0-9.-]*):(/[^/<\s"][^<\s"]*)`)
+	// geo-replication secondaries like ssh://user@host::volume
+	geoRepSecondaryPattern = regexp.MustCompile(`(?:[A-Za-z]+://)?(?:[A-Za-z0-9_.-]+@)?([A-Za-z0-9][A-Za-z0-9.-]*)::`)
+
+	// plain text outputs like heal statistics and scrub status
+	textHostnamePattern = regexp.MustCompile(`(?:Hostname of brick|Node:) (\S+)`)
+	textPathPattern     = regexp.MustCompile(`(?:BRICK|path): (/\S+)`)
+
+	// documentationNets are the IPv4 ranges reserved for documentation
+	documentationNets = []string{"192.0.2", "198.51.100", "203.0.113"}
+)
+
+// Anonymizer replaces hostnames, IPs, UUIDs and paths in gluster output.
+// Every value is mapped to the same replacement in all outputs, so the
+// relations between bricks, peers and volumes survive. Values have to be
+// learned from all outputs before any of them is replaced.
+type Anonymizer struct {
+	replacements map[string]string
+	hosts        int
+	ips          int
+	uuids        int
+	paths        int
+}
+
+// NewAnonymizer returns an Anonymizer without any learned values
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{replacements: map[string]string{}}
+}
+
+// Learn collects the sensitive values of data
+func (a *Anonymizer) Learn(data []byte) {
+	text := string(data)
+	for _, uuid := range uuidPattern.FindAllString(text, -1) {
+		a.add(uuid, func() string {
+			a.uuids++
+			return fmt.Sprintf("00000000-0000-4000-8000-%012d", a.uuids)
+		})
+	}
+	for _, ip := range ipv4Pattern.FindAllString(text, -1) {
+		a.add(ip, func() string {
+			a.ips++
+			return anonymousIP(a.ips)
+		})
+	}
+	for _, match := range hostnamePattern.FindAllStringSubmatch(text, -1) {
+		a.addHost(match[1])
+	}
+	for _, match := range textHostnamePattern.FindAllStringSubmatch(text, -1) {
+		a.addHost(match[1])
+	}
+	for _, match := range hostPathPattern.FindAllStringSubmatch(text, -1) {
+		a.addHost(match[1])
+		a.addPath(match[2])
+	}
+	for _, match := range geoRepSecondaryPattern.FindAllStringSubmatch(text, -1) {
+		a.addHost(match[1])
+	}
+	for _, match := range pathPattern.FindAllStringSubmatch(text, -1) {
+		a.addPath(match[1])
+	}
+	for _, match := range textPathPattern.FindAllStringSubmatch(text, -1) {
+		a.addPath(match[1])
+	}
+}
+
+// Apply returns data with every learned value replaced. Values are only
+// replaced as whole tokens, so the host node1 doesn't change the volume name
+// gv_node1_data.
+func (a *Anonymizer) Apply(data []byte) []byte {
+	if len(a.replacements) == 0 {
+		return data
+	}
+
+	// candidates by their first byte, longest values first, so
+	// /bricks/gv_test2 isn't replaced as /bricks/gv_test
+	candidates := map[byte][]string{}
+	for value := range a.replacements {
+		candidates[value[0]] = append(candidates[value[0]], value)
+	}
+	for _, values := range candidates {
+		slices.SortFunc(values, func(x, y string) int {
+			if len(x) != len(y) {
+				return len(y) - len(x)
+			}
+			return strings.Compare(x, y)
+		})
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(data))
+	for i := 0; i < len(data); {
+		value := tokenAt(data, i, candidates[data[i]])
+		if value == "" {
+			out.WriteByte(data[i])
+			i++
+			continue
+		}
+		out.WriteString(a.replacements[value])
+		i += len(value)
+	}
+	return out.Bytes()
+}
+
+// tokenAt returns the first of values that is at position i of data and not
+// part of a longer token
+func tokenAt(data []byte, i int, values []string) string {
+	for _, value := range values {
+		end := i + len(value)
+		if end > len(data) || string(data[i:end]) != value {
+			continue
+		}
+		if isTokenByte(value[0]) && i > 0 && isTokenByte(data[i-1]) {
+			continue
+		}
+		if isTokenByte(value[len(value)-1]) && end < len(data) && isTokenByte(data[end]) {
+			continue
+		}
+		return value
+	}
+	return ""
+}
+
+// isTokenByte reports whether b is part of names like hosts and volumes
+func isTokenByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '.' || b == '_' || b == '-'
+}
+
+func (a *Anonymizer) addHost(host string) {
+	host = strings.TrimSpace(host)
+	if host == "" || host == "localhost" || ipv4Pattern.MatchString(host) {
+		return
+	}
+	a.add(host, func() string {
+		a.hosts++
+		return fmt.Sprintf("host%d.example.invalid", a.hosts)
+	})
+}
+
+func (a *Anonymizer) addPath(path string) {
+	path = strings.TrimSpace(path)
+	if path == "" || path == "/" {
+		return
+	}
+	a.add(path, func() string {
+		a.paths++
+		return fmt.Sprintf("/anonymized/path%d", a.paths)
+	})
+}
+
+func (a *Anonymizer) add(value string, replacement func() string) {
+	if _, ok := a.replacements[value]; !ok {
+		a.replacements[value] = replacement()
+	}
+}
+
+// anonymousIP returns the n-th replacement IP, starting at 1. They are taken
+// from the documentation ranges and then from 10.0.0.0/8, so no address is
+// handed out twice.
+func anonymousIP(n int) string {
+	if i := (n - 1) / 254; i < len(documentationNets) {
+		return fmt.Sprintf("%v.%d", documentationNets[i], (n-1)%254+1)
+	}
+	n -= len(documentationNets) * 254
+	return fmt.Sprintf("10.%d.%d.%d", n>>16, n>>8&255, n&255)
+}
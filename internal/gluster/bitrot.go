@@ -0,0 +1,146 @@
+package gluster
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scrubTimeLayout is the format of the last completed scrub time, in the
+// local time of the node
+const scrubTimeLayout = "2006-01-02 15:04:05"
+
+// CorruptedObject is a file the scrubber found corrupted. Older gluster
+// versions only report the GFID.
+type CorruptedObject struct {
+	GFID  string
+	Brick string
+	Path  string
+}
+
+// ScrubNode is the scrubber of a node in "gluster volume bitrot {volume} scrub status"
+type ScrubNode struct {
+	Node          string
+	ScrubbedFiles uint64
+	SkippedFiles  uint64
+	// LastCompleted is zero until the first scrub completed
+	LastCompleted time.Time
+	Duration      time.Duration
+	ErrorCount    uint64
+	Corrupted     []CorruptedObject
+}
+
+// ScrubStatus is the output of "gluster volume bitrot {volume} scrub status"
+type ScrubStatus struct {
+	Volume    string
+	State     string
+	Impact    string
+	Frequency string
+	Nodes     []ScrubNode
+}
+
+// GetVolumeScrubStatus executes "gluster volume bitrot {volume} scrub status",
+// which has no xml output. Volumes without bitrot detection fail, see BitrotDisabled.
+// The scrub times are read in the Location of the client.
+func (c *Client) GetVolumeScrubStatus(ctx context.Context, volumeName string) (ScrubStatus, error) {
+	args := []string{"volume", "bitrot", volumeName, "scrub", "status"}
+	out, err := execGlusterText(ctx, c, "volume bitrot scrub status", args...)
+	if err != nil {
+		return ScrubStatus{}, err
+	}
+	return ParseScrubStatus(out, c.Location)
+}
+
+// BitrotDisabled reports whether err is the failure of a bitrot command on a
+// volume without bitrot detection
+func BitrotDisabled(err error) bool {
+	var cliErr *CLIError
+	return errors.As(err, &cliErr) && cliErr.HasMessage("bitrot is not enabled")
+}
+
+// ParseScrubStatus parses the output of "gluster volume bitrot {volume} scrub status",
+// its times are in location
+func ParseScrubStatus(out string, location *time.Location) (ScrubStatus, error) {
+	var status ScrubStatus
+	var node *ScrubNode
+	corrupted := false
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, _ := strings.Cut(line, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		var err error
+		switch {
+		case line == "" || strings.HasPrefix(line, "====="):
+			continue
+		case key == "Node":
+			status.Nodes = append(status.Nodes, ScrubNode{Node: value})
+			node, corrupted = &status.Nodes[len(status.Nodes)-1], false
+		case node == nil:
+			switch key {
+			case "Volume name":
+				status.Volume = value
+			case "State of scrub":
+				status.State = value
+			case "Scrub impact":
+				status.Impact = value
+			case "Scrub frequency":
+				status.Frequency = value
+			}
+		case key == "Number of Scrubbed files":
+			node.ScrubbedFiles, err = strconv.ParseUint(value, 10, 64)
+		case key == "Number of Skipped files":
+			node.SkippedFiles, err = strconv.ParseUint(value, 10, 64)
+		case key == "Last completed scrub time":
+			// "Scrubber pending to complete." until the first scrub completed
+			if lastCompleted, parseErr := time.ParseInLocation(scrubTimeLayout, value, location); parseErr == nil {
+				node.LastCompleted = lastCompleted
+			}
+		case strings.HasPrefix(key, "Duration of last scrub"):
+			// the key itself contains colons, "Duration of last scrub (D:M:H:M:S): 0:0:12:34"
+			_, value, _ = strings.Cut(line, "):")
+			node.Duration, err = parseScrubDuration(strings.TrimSpace(value))
+		case key == "Error count":
+			node.ErrorCount, err = strconv.ParseUint(value, 10, 64)
+		case key == "Corrupted object's [GFID]":
+			corrupted = true
+		case !corrupted:
+			continue
+		case key == "path" && len(node.Corrupted) > 0:
+			node.Corrupted[len(node.Corrupted)-1].Path = value
+		default:
+			// newer versions add the brick, "<gfid> ==> BRICK: <brick>"
+			gfid, brick, _ := strings.Cut(line, "==> BRICK:")
+			node.Corrupted = append(node.Corrupted, CorruptedObject{GFID: strings.TrimSpace(gfid), Brick: strings.TrimSpace(brick)})
+		}
+		if err != nil {
+			return ScrubStatus{}, fmt.Errorf("couldn't parse %q: %w", line, err)
+		}
+	}
+	return status, scanner.Err()
+}
+
+// parseScrubDuration parses the days:hours:minutes:seconds gluster labels D:M:H:M:S
+func parseScrubDuration(value string) (time.Duration, error) {
+	units := []time.Duration{time.Second, time.Minute, time.Hour, 24 * time.Hour}
+	fields := strings.Split(value, ":")
+	if len(fields) > len(units) {
+		return 0, fmt.Errorf("too many fields in duration %q", value)
+	}
+
+	var duration time.Duration
+	for i, field := range fields {
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n) * units[len(fields)-1-i]
+	}
+	return duration, nil
+}
@@ -0,0 +1,184 @@
+package gluster
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// BundleManifestName is the file in a bundle describing the recorded calls
+const BundleManifestName = "manifest.json"
+
+// BundleCall describes a recorded gluster invocation inside a bundle
+type BundleCall struct {
+	Args     []string `json:"args"`
+	File     string   `json:"file"`
+	ExitCode int      `json:"exitCode"`
+	Stderr   string   `json:"stderr,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// BundleManifest is stored as manifest.json next to the recorded outputs
+type BundleManifest struct {
+	CreatedAt  time.Time    `json:"createdAt"`
+	Version    string       `json:"version"`
+	Anonymized bool         `json:"anonymized"`
+	Calls      []BundleCall `json:"calls"`
+}
+
+// WriteBundle writes calls as gzipped tarball to w. Every output is stored
+// under its FixtureName, exit codes, stderr and the gluster version go to
+// manifest.json. With an anonymizer, hostnames, IPs, UUIDs and paths are
+// replaced consistently across all files.
+func WriteBundle(w io.Writer, calls []Call, version string, anonymizer *Anonymizer) error {
+	manifest := BundleManifest{
+		CreatedAt:  time.Now().UTC(),
+		Version:    strings.TrimSpace(version),
+		Anonymized: anonymizer != nil,
+	}
+
+	anonymize := func(data []byte) []byte { return data }
+	if anonymizer != nil {
+		for _, call := range calls {
+			anonymizer.Learn(call.Result.Stdout)
+			anonymizer.Learn(call.Result.Stderr)
+		}
+		anonymize = anonymizer.Apply
+	}
+
+	files := map[string][]byte{}
+	for _, call := range calls {
+		// remove-brick takes bricks as arguments, which are replayed with the anonymized names
+		args := make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			args[i] = string(anonymize([]byte(arg)))
+		}
+		bundleCall := BundleCall{
+			Args:     args,
+			File:     FixtureName(args),
+			ExitCode: call.Result.ExitCode,
+			Stderr:   string(anonymize(call.Result.Stderr)),
+		}
+		if call.Err != nil {
+			bundleCall.Error = string(anonymize([]byte(call.Err.Error())))
+		}
+		manifest.Calls = append(manifest.Calls, bundleCall)
+		files[bundleCall.File] = anonymize(call.Result.Stdout)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	writeFile := func(name string, data []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: manifest.CreatedAt,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(data)
+		return err
+	}
+
+	if err := writeFile(BundleManifestName, manifestData); err != nil {
+		return err
+	}
+	for _, call := range manifest.Calls {
+		data, ok := files[call.File]
+		if !ok {
+			continue
+		}
+		if err := writeFile(call.File, data); err != nil {
+			return err
+		}
+		delete(files, call.File)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// OpenFixtures returns a FixtureRunner for a directory of xml files or for a
+// bundle written by WriteBundle. Recorded exit codes and stderr are replayed
+// when a manifest.json is present.
+func OpenFixtures(name string) (*FixtureRunner, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := NewFixtureRunner(name)
+	if !info.IsDir() {
+		if runner.bundle, err = readBundle(name); err != nil {
+			return nil, fmt.Errorf("couldn't read bundle %v: %w", name, err)
+		}
+	}
+
+	manifestData, err := runner.readFile(BundleManifestName)
+	if errors.Is(err, os.ErrNotExist) {
+		return runner, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest BundleManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("couldn't parse %v: %w", BundleManifestName, err)
+	}
+	runner.calls = make(map[string]BundleCall, len(manifest.Calls))
+	for _, call := range manifest.Calls {
+		runner.calls[strings.Join(call.Args, " ")] = call
+	}
+	return runner, nil
+}
+
+// readBundle reads all regular files of a gzipped tarball into memory
+func readBundle(name string) (map[string][]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	files := map[string][]byte{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Base(header.Name)] = data
+	}
+}
@@ -0,0 +1,143 @@
+package gluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func recordFixtures(t *testing.T, anonymizer *Anonymizer) string {
+	t.Helper()
+	recorder := NewRecordingRunner(NewFixtureRunner("../../test"))
+	client := NewClient(recorder)
+	ctx := context.Background()
+	if _, err := client.GetVolumeInfo(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetPeerStatus(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = client.GetVolumeProfileGvInfoCumulative(ctx, "gv_missing")
+
+	bundle := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	buff := &bytes.Buffer{}
+	if err := WriteBundle(buff, recorder.Calls(), "glusterfs 11.1\n", anonymizer); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bundle, buff.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestBundleReplay(t *testing.T) {
+	runner, err := OpenFixtures(recordFixtures(t, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(runner)
+
+	volumeInfo, err := client.GetVolumeInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volumeInfo.VolInfo.Volumes.Count != 2 {
+		t.Errorf("expected 2 volumes from the bundle and got %v", volumeInfo.VolInfo.Volumes.Count)
+	}
+
+	_, err = client.GetVolumeProfileGvInfoCumulative(context.Background(), "gv_missing")
+	if err == nil || !strings.Contains(err.Error(), "no fixture") {
+		t.Errorf("expected the recorded error to be replayed and got %v", err)
+	}
+}
+
+func TestBundleAnonymize(t *testing.T) {
+	runner, err := OpenFixtures(recordFixtures(t, NewAnonymizer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"gluster_volume_info.xml", "gluster_peer_status.xml"} {
+		dat, err := runner.readFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range []string{"example.local", "/mnt/gluster", "a049c424-ffff-4436-abd4-ef3fc3fffffa"} {
+			if bytes.Contains(dat, []byte(secret)) {
+				t.Errorf("%v still contains %v", name, secret)
+			}
+		}
+	}
+
+	volumeInfo, err := NewClient(runner).GetVolumeInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume := volumeInfo.VolInfo.Volumes.Volume[0]
+	if volume.Name != "gv_cluster" {
+		t.Errorf("volume names are kept, got %v", volume.Name)
+	}
+	first, second := volume.Bricks[0].Name, volumeInfo.VolInfo.Volumes.Volume[1].Bricks[0].Name
+	if strings.Split(first, ":")[0] != strings.Split(second, ":")[0] {
+		t.Errorf("the same host was anonymized differently: %v and %v", first, second)
+	}
+}
+
+func TestAnonymizeManyIPs(t *testing.T) {
+	var lines []string
+	for i := range 1000 {
+		lines = append(lines, fmt.Sprintf("<hostname>172.16.%d.%d</hostname>", i/200, i%200+1))
+	}
+	data := []byte(strings.Join(lines, "\n"))
+
+	anonymizer := NewAnonymizer()
+	anonymizer.Learn(data)
+	replaced := strings.Split(string(anonymizer.Apply(data)), "\n")
+
+	seen := map[string]bool{}
+	for _, line := range replaced {
+		if strings.Contains(line, "172.16.") {
+			t.Fatalf("%v wasn't anonymized", line)
+		}
+		if seen[line] {
+			t.Fatalf("%v was handed out twice", line)
+		}
+		seen[line] = true
+	}
+}
+
+func TestAnonymizeTokens(t *testing.T) {
+	data := []byte(`<volume><name>gv_node1_sshfs</name><brick>node1:/mnt/gluster/gv_node1_sshfs</brick>
+<option><name>transport.ssh</name><value>on</value></option></volume>
+<pair><primary_node>node1</primary_node><slave>ssh://root@dr1::gv_dr</slave></pair>`)
+
+	anonymizer := NewAnonymizer()
+	anonymizer.Learn(data)
+	anonymized := string(anonymizer.Apply(data))
+
+	for _, kept := range []string{"<name>gv_node1_sshfs</name>", "transport.ssh", "ssh://root@", "::gv_dr"} {
+		if !strings.Contains(anonymized, kept) {
+			t.Errorf("expected %v to be kept in %v", kept, anonymized)
+		}
+	}
+	for _, secret := range []string{"node1:", ">node1<", "@dr1:", "/mnt/gluster"} {
+		if strings.Contains(anonymized, secret) {
+			t.Errorf("expected %v to be anonymized in %v", secret, anonymized)
+		}
+	}
+	if _, ok := anonymizer.replacements["ssh"]; ok {
+		t.Error("the scheme of the secondary was learned as a host")
+	}
+}
+
+func TestOpenFixturesMissing(t *testing.T) {
+	_, err := OpenFixtures(filepath.Join(t.TempDir(), "missing"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error and got %v", err)
+	}
+}
@@ -2,29 +2,214 @@ package gluster
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/nilpntr/gluster-exporter/internal/utils"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-func execGlusterCommand(arg ...string) (*bytes.Buffer, error) {
-	stdoutBuffer := &bytes.Buffer{}
-	argXML := append(arg, "--xml")
-	glusterExec := exec.Command(viper.GetString("gluster_binary"), argXML...)
-	glusterExec.Stdout = stdoutBuffer
-	err := glusterExec.Run()
+// DefaultTimeout is used for commands without a configured timeout
+const DefaultTimeout = 30 * time.Second
+
+// AllVolumes is the --gluster.volumes value selecting every volume
+const AllVolumes = "_all"
+
+// ErrTimeout is returned when a gluster command didn't finish within its timeout
+var ErrTimeout = errors.New("gluster command timed out")
+
+// Client runs gluster CLI commands through a Runner and decodes their xml output
+type Client struct {
+	// Timeout bounds commands without an entry in Timeouts, zero disables it
+	Timeout time.Duration
+	// Timeouts overrides Timeout per subcommand, e.g. "volume heal info" or
+	// "volume status". The longest matching subcommand wins.
+	Timeouts map[string]time.Duration
+	// Retries is how often a command is retried when glusterd reports lock
+	// contention, retries never outlast the command's timeout
+	Retries int
+	// RetryBackoff is the base of the jittered exponential backoff between retries
+	RetryBackoff time.Duration
+	// Location is the time zone of the gluster nodes, the CLI prints the
+	// timestamps of heal statistics, geo-replication, snapshots and bitrot
+	// scrubs in their local time
+	Location *time.Location
+
+	runner Runner
+
+	mu       sync.Mutex
+	timedOut map[string]uint64
+	retried  map[RetryKey]uint64
+}
+
+// NewClient returns a Client executing its commands with runner
+func NewClient(runner Runner) *Client {
+	return &Client{
+		Timeout:      DefaultTimeout,
+		Timeouts:     map[string]time.Duration{},
+		Retries:      DefaultRetries,
+		RetryBackoff: DefaultRetryBackoff,
+		Location:     time.Local,
+		runner:       runner,
+		timedOut:     map[string]uint64{},
+		retried:      map[RetryKey]uint64{},
+	}
+}
+
+// Runner returns the Runner the client executes its commands with
+func (c *Client) Runner() Runner {
+	return c.runner
+}
+
+// Local reports whether the client talks to the glusterd of this machine,
+// node local checks like mounts are only meaningful then
+func (c *Client) Local() bool {
+	return isLocal(c.runner)
+}
+
+// TimeoutCounts returns how many times each subcommand ran into its timeout
+func (c *Client) TimeoutCounts() map[string]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.timedOut)
+}
+
+// RetryCounts returns how many times each subcommand was retried and why
+func (c *Client) RetryCounts() map[RetryKey]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.retried)
+}
+
+// timeoutFor returns the timeout of command, falling back to shorter
+// subcommands and finally to Timeout
+func (c *Client) timeoutFor(command string) time.Duration {
+	words := strings.Fields(command)
+	for i := len(words); i > 0; i-- {
+		if timeout, ok := c.Timeouts[strings.Join(words[:i], " ")]; ok {
+			return timeout
+		}
+	}
+	return c.Timeout
+}
+
+// execGlusterCommand runs arg. command names the subcommand without volume
+// names and is used for timeouts and metrics.
+func (c *Client) execGlusterCommand(ctx context.Context, command string, arg ...string) (Result, error) {
+	if timeout := c.timeoutFor(command); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result, err := c.runner.Run(ctx, arg...)
+	for attempt := 0; err == nil && attempt < c.Retries; attempt++ {
+		reason := lockContention(result)
+		if reason == "" {
+			break
+		}
+		delay := retryDelay(c.RetryBackoff, attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			break
+		}
+
+		zap.L().Sugar().Debugf("glusterd is locked (%v) while executing %v, retrying in %v", reason, arg, delay)
+		c.mu.Lock()
+		c.retried[RetryKey{Command: command, Reason: reason}]++
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-time.After(delay):
+			result, err = c.runner.Run(ctx, arg...)
+		}
+	}
 
 	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			c.mu.Lock()
+			c.timedOut[command]++
+			c.mu.Unlock()
+			err = fmt.Errorf("%w: %v after %v", ErrTimeout, arg, c.timeoutFor(command))
+		}
 		zap.L().Sugar().Errorf("tried to execute %v and got error: %v", arg, err)
-		return stdoutBuffer, err
+		return result, err
+	}
+	return result, nil
+}
+
+// cliOutput is implemented by every xml type embedding OpStatus
+type cliOutput interface {
+	opStatus() OpStatus
+}
+
+// execGlusterXML runs a gluster command and decodes its output into T. A non
+// zero exit code or opRet/opErrno is returned as *CLIError together with
+// whatever could be decoded.
+func execGlusterXML[T cliOutput](ctx context.Context, c *Client, command string, arg ...string) (T, error) {
+	result, cmdErr := c.execGlusterCommand(ctx, command, append(slices.Clip(arg), "--xml")...)
+	if cmdErr != nil {
+		var empty T
+		return empty, cmdErr
+	}
+
+	output, decodeErr := utils.DecodeXml[T](bytes.NewReader(result.Stdout))
+	status := output.opStatus()
+	if result.ExitCode != 0 || status.OpRet != 0 || status.OpErrno != 0 {
+		cliErr := &CLIError{
+			Command:  command,
+			Args:     arg,
+			ExitCode: result.ExitCode,
+			OpRet:    status.OpRet,
+			OpErrno:  status.OpErrno,
+			OpErrstr: status.OpErrstr,
+			Stderr:   strings.TrimSpace(string(result.Stderr)),
+		}
+		if decodeErr != nil && cliErr.Stderr == "" {
+			cliErr.Stderr = strings.TrimSpace(string(result.Stdout))
+		}
+		zap.L().Sugar().Errorf("tried to execute %v and got error: %v", arg, cliErr)
+		return output, cliErr
+	}
+	if decodeErr != nil {
+		zap.L().Sugar().Errorf("Something went wrong while unmarshalling xml: %v", decodeErr)
+		return output, decodeErr
 	}
-	return stdoutBuffer, nil
+	return output, nil
+}
+
+// execGlusterText runs a gluster command without xml output, for the
+// commands whose xml output lacks the details. A non zero exit code is
+// returned as *CLIError.
+func execGlusterText(ctx context.Context, c *Client, command string, arg ...string) (string, error) {
+	result, err := c.execGlusterCommand(ctx, command, arg...)
+	if err != nil {
+		return "", err
+	}
+
+	if result.ExitCode != 0 {
+		cliErr := &CLIError{
+			Command:  command,
+			Args:     arg,
+			ExitCode: result.ExitCode,
+			Stderr:   strings.TrimSpace(string(result.Stderr)),
+		}
+		if cliErr.Stderr == "" {
+			cliErr.Stderr = strings.TrimSpace(string(result.Stdout))
+		}
+		zap.L().Sugar().Errorf("tried to execute %v and got error: %v", arg, cliErr)
+		return string(result.Stdout), cliErr
+	}
+	return string(result.Stdout), nil
 }
 
 func GetMountCheck() (*bytes.Buffer, error) {
@@ -70,127 +255,182 @@ func ExecTouchOnVolumes(mountpoint string) (bool, error) {
 
 // GetVolumeInfo executes "gluster volume info" at the local machine and
 // returns VolumeInfoXML struct and error
-func GetVolumeInfo() (VolumeInfoXML, error) {
+func (c *Client) GetVolumeInfo(ctx context.Context) (VolumeInfoXML, error) {
 	args := []string{"volume", "info"}
-	bytesBuffer, cmdErr := execGlusterCommand(args...)
-	if cmdErr != nil {
-		return VolumeInfoXML{}, cmdErr
-	}
-	volumeInfo, err := utils.DecodeXml[VolumeInfoXML](bytesBuffer)
-	if err != nil {
-		zap.L().Sugar().Errorf("Something went wrong while unmarshalling xml: %v", err)
-		return volumeInfo, err
-	}
-
-	return volumeInfo, nil
+	return execGlusterXML[VolumeInfoXML](ctx, c, "volume info", args...)
 }
 
-// GetVolumeList executes "gluster volume info" at the local machine and
-// returns VolumeList struct and error
-func GetVolumeList() (VolList, error) {
+// GetVolumeList executes "gluster volume list" and returns the names of all
+// volumes
+func (c *Client) GetVolumeList(ctx context.Context) (VolList, error) {
 	args := []string{"volume", "list"}
-	bytesBuffer, cmdErr := execGlusterCommand(args...)
-	if cmdErr != nil {
-		return VolList{}, cmdErr
-	}
-	volumeList, err := utils.DecodeXml[VolumeListXML](bytesBuffer)
-	if err != nil {
-		zap.L().Sugar().Errorf("Something went wrong while unmarshalling xml: %v", err)
-		return volumeList.VolList, err
-	}
-
-	return volumeList.VolList, nil
+	volumeList, err := execGlusterXML[VolumeListXML](ctx, c, "volume list", args...)
+	return volumeList.VolList, err
 }
 
 // GetPeerStatus executes "gluster peer status" at the local machine and
 // returns PeerStatus struct and error
-func GetPeerStatus() (PeerStatus, error) {
+func (c *Client) GetPeerStatus(ctx context.Context) (PeerStatus, error) {
 	args := []string{"peer", "status"}
-	bytesBuffer, cmdErr := execGlusterCommand(args...)
-	if cmdErr != nil {
-		return PeerStatus{}, cmdErr
-	}
-	peerStatus, err := utils.DecodeXml[PeerStatusXML](bytesBuffer)
-	if err != nil {
-		zap.L().Sugar().Errorf("Something went wrong while unmarshalling xml: %v", err)
-		return peerStatus.PeerStatus, err
-	}
-
-	return peerStatus.PeerStatus, nil
+	peerStatus, err := execGlusterXML[PeerStatusXML](ctx, c, "peer status", args...)
+	return peerStatus.PeerStatus, err
 }
 
 // GetVolumeProfileGvInfoCumulative executes "gluster volume {volume] profile info cumulative" at the local machine and
 // returns VolumeInfoXML struct and error
-func GetVolumeProfileGvInfoCumulative(volumeName string) (VolProfile, error) {
+func (c *Client) GetVolumeProfileGvInfoCumulative(ctx context.Context, volumeName string) (VolProfile, error) {
 	args := []string{"volume", "profile", volumeName, "info", "cumulative"}
-	bytesBuffer, cmdErr := execGlusterCommand(args...)
-	if cmdErr != nil {
-		return VolProfile{}, cmdErr
-	}
-	volumeProfile, err := utils.DecodeXml[VolumeProfileXML](bytesBuffer)
-	if err != nil {
-		zap.L().Sugar().Errorf("Something went wrong while unmarshalling xml: %v", err)
-		return volumeProfile.VolProfile, err
-	}
-	return volumeProfile.VolProfile, nil
+	volumeProfile, err := execGlusterXML[VolumeProfileXML](ctx, c, "volume profile info cumulative", args...)
+	return volumeProfile.VolProfile, err
 }
 
 // GetVolumeStatusAllDetail executes "gluster volume status all detail" at the local machine
 // returns VolumeStatusXML struct and error
-func GetVolumeStatusAllDetail() (VolumeStatusXML, error) {
+func (c *Client) GetVolumeStatusAllDetail(ctx context.Context) (VolumeStatusXML, error) {
 	args := []string{"volume", "status", "all", "detail"}
-	bytesBuffer, cmdErr := execGlusterCommand(args...)
-	if cmdErr != nil {
-		return VolumeStatusXML{}, cmdErr
-	}
-	volumeStatus, err := utils.DecodeXml[VolumeStatusXML](bytesBuffer)
-	if err != nil {
-		zap.L().Sugar().Errorf("Something went wrong while unmarshalling xml: %v", err)
-		return volumeStatus, err
-	}
-	return volumeStatus, nil
+	return execGlusterXML[VolumeStatusXML](ctx, c, "volume status all detail", args...)
 }
 
-// GetVolumeHealInfo executes volume heal info on host system and processes input
-// returns (int) number of unsynced files
-func GetVolumeHealInfo(volumeName string) (int, error) {
+// GetVolumeHealInfo executes "gluster volume heal {volume} info" and returns
+// the entries that need healing of every brick
+func (c *Client) GetVolumeHealInfo(ctx context.Context, volumeName string) (VolumeHealInfoXML, error) {
 	args := []string{"volume", "heal", volumeName, "info"}
-	entriesOutOfSync := 0
-	bytesBuffer, cmdErr := execGlusterCommand(args...)
-	if cmdErr != nil {
-		return -1, cmdErr
-	}
-	healInfo, err := utils.DecodeXml[VolumeHealInfoXML](bytesBuffer)
-	if err != nil {
-		zap.L().Sugar().Error(err)
-		return -1, err
-	}
+	return execGlusterXML[VolumeHealInfoXML](ctx, c, "volume heal info", args...)
+}
 
-	for _, brick := range healInfo.HealInfo.Bricks.Brick {
-		var count int
-		var err error
-		count, err = strconv.Atoi(brick.NumberOfEntries)
-		if err != nil {
-			zap.L().Sugar().Error(err)
-			return -1, err
-		}
-		entriesOutOfSync += count
-	}
-	return entriesOutOfSync, nil
+// GetVolumeHealInfoSummary executes "gluster volume heal {volume} info summary"
+// and returns the total, pending, split-brain and possibly healing counts of
+// every brick without listing the entries. Older gluster versions answer with
+// their usage instead.
+func (c *Client) GetVolumeHealInfoSummary(ctx context.Context, volumeName string) (VolumeHealInfoXML, error) {
+	args := []string{"volume", "heal", volumeName, "info", "summary"}
+	return execGlusterXML[VolumeHealInfoXML](ctx, c, "volume heal info summary", args...)
+}
+
+// GetVolumeHealInfoSplitBrain executes "gluster volume heal {volume} info split-brain"
+// and returns the entries in split-brain of every brick
+func (c *Client) GetVolumeHealInfoSplitBrain(ctx context.Context, volumeName string) (VolumeHealInfoXML, error) {
+	args := []string{"volume", "heal", volumeName, "info", "split-brain"}
+	return execGlusterXML[VolumeHealInfoXML](ctx, c, "volume heal info split-brain", args...)
 }
 
 // GetVolumeQuotaList executes volume quota list on host system and processes input
 // returns QuotaList structs and errors
-func GetVolumeQuotaList(volumeName string) (VolumeQuotaXML, error) {
+func (c *Client) GetVolumeQuotaList(ctx context.Context, volumeName string) (VolumeQuotaXML, error) {
 	args := []string{"volume", "quota", volumeName, "list"}
-	bytesBuffer, cmdErr := execGlusterCommand(args...)
-	if cmdErr != nil {
-		return VolumeQuotaXML{}, cmdErr
+	return execGlusterXML[VolumeQuotaXML](ctx, c, "volume quota list", args...)
+}
+
+// GetVolumeStatusClients executes "gluster volume status {volume} clients" and
+// returns the client connections of every brick
+func (c *Client) GetVolumeStatusClients(ctx context.Context, volumeName string) (VolumeStatusClientsXML, error) {
+	args := []string{"volume", "status", volumeName, "clients"}
+	return execGlusterXML[VolumeStatusClientsXML](ctx, c, "volume status clients", args...)
+}
+
+// GetVolumeStatusMem executes "gluster volume status {volume} mem" and
+// returns the malloc and mempool statistics of every brick process
+func (c *Client) GetVolumeStatusMem(ctx context.Context, volumeName string) (VolumeStatusMemXML, error) {
+	args := []string{"volume", "status", volumeName, "mem"}
+	return execGlusterXML[VolumeStatusMemXML](ctx, c, "volume status mem", args...)
+}
+
+// GetVolumeStatusFd executes "gluster volume status {volume} fd" and
+// returns the fd tables of every client connection of every brick
+func (c *Client) GetVolumeStatusFd(ctx context.Context, volumeName string) (VolumeStatusFdXML, error) {
+	args := []string{"volume", "status", volumeName, "fd"}
+	return execGlusterXML[VolumeStatusFdXML](ctx, c, "volume status fd", args...)
+}
+
+// GetVolumeStatusInode executes "gluster volume status {volume} inode" and
+// returns the inode tables of every brick
+func (c *Client) GetVolumeStatusInode(ctx context.Context, volumeName string) (VolumeStatusInodeXML, error) {
+	args := []string{"volume", "status", volumeName, "inode"}
+	return execGlusterXML[VolumeStatusInodeXML](ctx, c, "volume status inode", args...)
+}
+
+// GetVolumeStatusCallpool executes "gluster volume status {volume} callpool"
+// and returns the pending call stacks of every brick
+func (c *Client) GetVolumeStatusCallpool(ctx context.Context, volumeName string) (VolumeStatusCallpoolXML, error) {
+	args := []string{"volume", "status", volumeName, "callpool"}
+	return execGlusterXML[VolumeStatusCallpoolXML](ctx, c, "volume status callpool", args...)
+}
+
+// GetVolumeTop executes "gluster volume top {volume} {op} list-cnt {listCount}"
+// and returns the busiest files of every brick for op
+func (c *Client) GetVolumeTop(ctx context.Context, volumeName string, op string, listCount int) (VolumeTopXML, error) {
+	args := []string{"volume", "top", volumeName, op, "list-cnt", strconv.Itoa(listCount)}
+	return execGlusterXML[VolumeTopXML](ctx, c, "volume top", args...)
+}
+
+// GetVolumeStatusTasks executes "gluster volume status {volume} tasks" and
+// returns the rebalance and remove-brick tasks of the volume
+func (c *Client) GetVolumeStatusTasks(ctx context.Context, volumeName string) (VolumeStatusTasksXML, error) {
+	args := []string{"volume", "status", volumeName, "tasks"}
+	return execGlusterXML[VolumeStatusTasksXML](ctx, c, "volume status tasks", args...)
+}
+
+// GetVolumeRebalanceStatus executes "gluster volume rebalance {volume} status"
+// and returns the progress of the rebalance on every node
+func (c *Client) GetVolumeRebalanceStatus(ctx context.Context, volumeName string) (VolumeRebalanceXML, error) {
+	args := []string{"volume", "rebalance", volumeName, "status"}
+	return execGlusterXML[VolumeRebalanceXML](ctx, c, "volume rebalance status", args...)
+}
+
+// GetVolumeRemoveBrickStatus executes "gluster volume remove-brick {volume} {bricks} status"
+// and returns the progress of migrating the data off bricks on every node
+func (c *Client) GetVolumeRemoveBrickStatus(ctx context.Context, volumeName string, bricks []string) (VolumeRemoveBrickXML, error) {
+	args := append(append([]string{"volume", "remove-brick", volumeName}, bricks...), "status")
+	return execGlusterXML[VolumeRemoveBrickXML](ctx, c, "volume remove-brick status", args...)
+}
+
+// GetVolumeOptions executes "gluster volume get {volume} all" and returns the
+// effective value of every option, including the ones left at their default
+func (c *Client) GetVolumeOptions(ctx context.Context, volumeName string) (VolumeGetOptsXML, error) {
+	args := []string{"volume", "get", volumeName, "all"}
+	return execGlusterXML[VolumeGetOptsXML](ctx, c, "volume get", args...)
+}
+
+// GetSnapshotInfo executes "gluster snapshot info" and returns the snapshots
+// of all volumes
+func (c *Client) GetSnapshotInfo(ctx context.Context) (SnapshotInfoXML, error) {
+	args := []string{"snapshot", "info"}
+	return execGlusterXML[SnapshotInfoXML](ctx, c, "snapshot info", args...)
+}
+
+// GetSnapshotConfig executes "gluster snapshot config" and returns the
+// snapshot limits of the cluster and every volume
+func (c *Client) GetSnapshotConfig(ctx context.Context) (SnapshotConfigXML, error) {
+	args := []string{"snapshot", "config"}
+	return execGlusterXML[SnapshotConfigXML](ctx, c, "snapshot config", args...)
+}
+
+// GetGeoRepStatusDetail executes "gluster volume geo-replication status detail"
+// and returns the workers of all geo-replication sessions
+func (c *Client) GetGeoRepStatusDetail(ctx context.Context) (VolumeGeoRepStatusXML, error) {
+	args := []string{"volume", "geo-replication", "status", "detail"}
+	geoRep, err := execGlusterXML[VolumeGeoRepStatusXML](ctx, c, "volume geo-replication status", args...)
+	for _, volume := range geoRep.GeoRep.Volume {
+		for _, session := range volume.Sessions.Session {
+			for i := range session.Pair {
+				pair := &session.Pair[i]
+				if pair.MasterNode == "" {
+					pair.MasterNode, pair.MasterBrick = pair.PrimaryNode, pair.PrimaryBrick
+					pair.SlaveUser, pair.Slave, pair.SlaveNode = pair.SecondaryUser, pair.Secondary, pair.SecondaryNode
+				}
+			}
+		}
 	}
-	volumeQuota, err := utils.DecodeXml[VolumeQuotaXML](bytesBuffer)
-	if err != nil {
-		zap.L().Sugar().Errorf("Something went wrong while unmarshalling xml: %v", err)
-		return volumeQuota, err
+	return geoRep, err
+}
+
+// GeoRepSlave splits the slave of a geo-replication session, e.g.
+// ssh://geoaccount@dr1.example.com::gv_dr, into its host and volume
+func GeoRepSlave(slave string) (host string, volume string) {
+	slave = strings.TrimPrefix(slave, "ssh://")
+	host, volume, _ = strings.Cut(slave, "::")
+	if _, afterUser, ok := strings.Cut(host, "@"); ok {
+		host = afterUser
 	}
-	return volumeQuota, nil
+	return host, volume
 }
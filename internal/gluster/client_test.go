@@ -1,8 +1,11 @@
 package gluster
 
 import (
+	"context"
+	"errors"
 	"slices"
 	"testing"
+	"time"
 )
 
 func TestContainsVolume(t *testing.T) {
@@ -45,3 +48,315 @@ func TestParseMountOutput(t *testing.T) {
 	}
 
 }
+
+func TestFixtureRunner(t *testing.T) {
+	runner := NewFixtureRunner("../../test")
+
+	var tests = []struct {
+		args    []string
+		wantErr bool
+	}{
+		{args: []string{"volume", "info", "--xml"}},
+		{args: []string{"volume", "profile", "gv_test", "info", "cumulative", "--xml"}},
+		{args: []string{"volume", "heal", "gv_cluster", "info", "--xml"}},
+		{args: []string{"volume", "heal", "gv_test", "statistics"}},
+		{args: []string{"volume", "remove-brick", "gv_test", "host4.example.local:/mnt/gluster/gv_test", "status", "--xml"}},
+		{args: []string{"volume", "rebalance", "gv_cluster", "status", "--xml"}, wantErr: true},
+	}
+	for _, c := range tests {
+		out, err := runner.Run(context.Background(), c.args...)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("expected an error for %v", c.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", c.args, err)
+			continue
+		}
+		if len(out.Stdout) == 0 {
+			t.Errorf("empty fixture for %v", c.args)
+		}
+	}
+}
+
+type blockingRunner struct{}
+
+func (blockingRunner) Run(ctx context.Context, _ ...string) (Result, error) {
+	<-ctx.Done()
+	return Result{}, ctx.Err()
+}
+
+func TestCommandTimeout(t *testing.T) {
+	client := NewClient(blockingRunner{})
+	client.Timeout = time.Hour
+	client.Timeouts["volume heal"] = 10 * time.Millisecond
+
+	_, err := client.GetVolumeHealInfo(context.Background(), "gv_test")
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout and got %v", err)
+	}
+
+	counts := client.TimeoutCounts()
+	if counts["volume heal info"] != 1 {
+		t.Errorf("expected one timeout for volume heal info and got %v", counts)
+	}
+}
+
+type staticRunner Result
+
+func (r staticRunner) Run(_ context.Context, _ ...string) (Result, error) {
+	return Result(r), nil
+}
+
+func TestCLIError(t *testing.T) {
+	var tests = []struct {
+		result   Result
+		opErrno  int
+		exitCode int
+		message  string
+	}{
+		{
+			result: Result{
+				Stdout: []byte("<cliOutput><opRet>-1</opRet><opErrno>30802</opErrno>" +
+					"<opErrstr>Another transaction is in progress for gv_test. Please try again after some time.</opErrstr></cliOutput>"),
+				ExitCode: 1,
+			},
+			opErrno:  ErrnoAnotherTransaction,
+			exitCode: 1,
+			message:  "another transaction is in progress",
+		},
+		{
+			result: Result{
+				Stdout:   []byte("<cliOutput><opRet>-1</opRet><opErrno>0</opErrno><opErrstr>quota command failed : Quota is disabled, please enable quota</opErrstr></cliOutput>"),
+				ExitCode: 1,
+			},
+			exitCode: 1,
+			message:  "quota is disabled",
+		},
+		{
+			result:   Result{Stderr: []byte("Connection failed. Please check if gluster daemon is operational.\n"), ExitCode: 1},
+			exitCode: 1,
+			message:  "gluster daemon is operational",
+		},
+	}
+	for _, c := range tests {
+		client := NewClient(staticRunner(c.result))
+		client.Retries = 0
+		_, err := client.GetVolumeQuotaList(context.Background(), "gv_test")
+
+		var cliErr *CLIError
+		if !errors.As(err, &cliErr) {
+			t.Errorf("expected a CLIError and got %v", err)
+			continue
+		}
+		if cliErr.Command != "volume quota list" {
+			t.Errorf("command is %v and volume quota list was expected", cliErr.Command)
+		}
+		if cliErr.OpErrno != c.opErrno || cliErr.ExitCode != c.exitCode {
+			t.Errorf("opErrno %v and exit code %v expected, got %v and %v", c.opErrno, c.exitCode, cliErr.OpErrno, cliErr.ExitCode)
+		}
+		if !cliErr.HasMessage(c.message) {
+			t.Errorf("expected %q in %v", c.message, cliErr)
+		}
+	}
+}
+
+type sequenceRunner struct {
+	results []Result
+	calls   int
+}
+
+func (r *sequenceRunner) Run(_ context.Context, _ ...string) (Result, error) {
+	result := r.results[min(r.calls, len(r.results)-1)]
+	r.calls++
+	return result, nil
+}
+
+func TestLockContentionRetry(t *testing.T) {
+	locked := Result{
+		Stdout:   []byte("<cliOutput><opRet>-1</opRet><opErrno>30802</opErrno><opErrstr>Another transaction is in progress. Please try again after some time.</opErrstr></cliOutput>"),
+		ExitCode: 1,
+	}
+	// some releases exit with 0 and only report the failure in the xml
+	lockedExitZero := Result{Stdout: locked.Stdout}
+	lockingFailed := Result{Stderr: []byte("Locking failed on node2.example.local. Please check log file for details.\n"), ExitCode: 1}
+	ok := Result{Stdout: []byte("<cliOutput><opRet>0</opRet><opErrno>0</opErrno><opErrstr/><volList><count>1</count><volume>gv_test</volume></volList></cliOutput>")}
+
+	runner := &sequenceRunner{results: []Result{locked, lockedExitZero, lockingFailed, ok}}
+	client := NewClient(runner)
+	client.RetryBackoff = time.Millisecond
+
+	volumeList, err := client.GetVolumeList(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volumeList.Count != 1 || runner.calls != 4 {
+		t.Errorf("expected 4 calls and one volume, got %v calls and %v", runner.calls, volumeList)
+	}
+
+	counts := client.RetryCounts()
+	if counts[RetryKey{Command: "volume list", Reason: RetryReasonAnotherTransaction}] != 2 {
+		t.Errorf("expected two %v retries and got %v", RetryReasonAnotherTransaction, counts)
+	}
+	if counts[RetryKey{Command: "volume list", Reason: RetryReasonLockingFailed}] != 1 {
+		t.Errorf("expected one %v retry and got %v", RetryReasonLockingFailed, counts)
+	}
+
+	runner = &sequenceRunner{results: []Result{locked}}
+	client = NewClient(runner)
+	client.RetryBackoff = time.Millisecond
+	_, err = client.GetVolumeList(context.Background())
+	var cliErr *CLIError
+	if !errors.As(err, &cliErr) || cliErr.OpErrno != ErrnoAnotherTransaction {
+		t.Errorf("expected the lock error after the last retry and got %v", err)
+	}
+	if runner.calls != DefaultRetries+1 {
+		t.Errorf("expected %v calls and got %v", DefaultRetries+1, runner.calls)
+	}
+}
+
+func TestExecRunnerArgs(t *testing.T) {
+	var tests = []struct {
+		runner   *ExecRunner
+		expected string
+		local    bool
+	}{
+		{runner: &ExecRunner{Binary: "gluster", Prefix: []string{"echo"}}, expected: "gluster volume info\n", local: true},
+		{runner: &ExecRunner{Binary: "gluster", Prefix: []string{"echo", "-n"}, GlusterdSocket: "/run/glusterd.socket"}, expected: "gluster --glusterd-sock=/run/glusterd.socket volume info", local: true},
+		{runner: &ExecRunner{Binary: "gluster", Prefix: []string{"echo", "-n"}, RemoteHost: "node2.example.local"}, expected: "gluster --remote-host=node2.example.local volume info"},
+	}
+	for _, c := range tests {
+		result, err := c.runner.Run(context.Background(), "volume", "info")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(result.Stdout) != c.expected {
+			t.Errorf("executed %q and %q was expected", result.Stdout, c.expected)
+		}
+		if NewClient(c.runner).Local() != c.local {
+			t.Errorf("expected local to be %v for %+v", c.local, c.runner)
+		}
+	}
+}
+
+func TestParseHealStatistics(t *testing.T) {
+	out, err := NewFixtureRunner("../../test").Run(context.Background(), "volume", "heal", "gv_test", "statistics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bricks, err := ParseHealStatistics(string(out.Stdout), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bricks) != 3 || bricks[2].Index != 2 || bricks[2].Hostname != "node3.example.com" || len(bricks[2].Crawls) != 0 {
+		t.Fatalf("bricks don't match: %+v", bricks)
+	}
+	crawls := bricks[0].Crawls
+	if len(crawls) != 3 {
+		t.Fatalf("expected 3 crawls on the first brick and got %+v", crawls)
+	}
+	expected := HealCrawl{
+		Type:       "INDEX",
+		Start:      time.Date(2024, 1, 15, 10, 10, 3, 0, time.UTC),
+		End:        time.Date(2024, 1, 15, 10, 10, 4, 0, time.UTC),
+		Healed:     3,
+		SplitBrain: 2,
+	}
+	if crawls[1] != expected {
+		t.Errorf("expected %+v and got %+v", expected, crawls[1])
+	}
+	if !crawls[2].InProgress() || crawls[2].Type != "FULL" || crawls[2].Healed != 418 {
+		t.Errorf("expected a running full crawl and got %+v", crawls[2])
+	}
+
+	if _, err := ParseHealStatistics("Crawl statistics for brick no 0\nStarting time of crawl: yesterday\n", time.UTC); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
+
+func TestParseHealCount(t *testing.T) {
+	out, err := NewFixtureRunner("../../test").Run(context.Background(), "volume", "heal", "gv_test", "statistics", "heal-count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bricks, err := ParseHealCount(string(out.Stdout))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []HealCountBrick{
+		{Name: "node1.example.com:/mnt/gluster/gv_test", Entries: 5},
+		{Name: "node2.example.com:/mnt/gluster/gv_test", Entries: 1},
+		{Name: "node3.example.com:/mnt/gluster/gv_test", Status: "Transport endpoint is not connected", Entries: -1},
+	}
+	if !slices.Equal(bricks, expected) {
+		t.Errorf("expected %+v and got %+v", expected, bricks)
+	}
+}
+
+func TestGeoRepPrimarySecondaryNames(t *testing.T) {
+	out := `<cliOutput><opRet>0</opRet><opErrno>0</opErrno><opErrstr/><geoRep><volume><name>gv_test</name><sessions><session>
+<pair><primary_node>node1</primary_node><primary_brick>/bricks/gv_test</primary_brick><secondary_user>root</secondary_user>
+<secondary>ssh://dr1::gv_dr</secondary><secondary_node>dr1</secondary_node><status>Active</status></pair>
+</session></sessions></volume></geoRep></cliOutput>`
+	client := NewClient(staticRunner{Stdout: []byte(out)})
+
+	geoRep, err := client.GetGeoRepStatusDetail(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair := geoRep.GeoRep.Volume[0].Sessions.Session[0].Pair[0]
+	if pair.MasterNode != "node1" || pair.MasterBrick != "/bricks/gv_test" || pair.Slave != "ssh://dr1::gv_dr" || pair.SlaveNode != "dr1" {
+		t.Errorf("expected the primary and secondary names in the master and slave fields, got %+v", pair)
+	}
+}
+
+func TestParseScrubStatus(t *testing.T) {
+	out, err := NewFixtureRunner("../../test").Run(context.Background(), "volume", "bitrot", "gv_test", "scrub", "status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := ParseScrubStatus(string(out.Stdout), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Volume != "gv_test" || status.State != "Active (Idle)" || len(status.Nodes) != 2 {
+		t.Fatalf("status doesn't match: %+v", status)
+	}
+
+	node := status.Nodes[0]
+	if node.Node != "localhost" || node.ScrubbedFiles != 152340 || node.SkippedFiles != 12 || node.ErrorCount != 2 {
+		t.Errorf("node doesn't match: %+v", node)
+	}
+	if !node.LastCompleted.Equal(time.Date(2024, 1, 15, 3, 12, 45, 0, time.UTC)) {
+		t.Errorf("expected the last scrub to complete at 2024-01-15 03:12:45 UTC, got %v", node.LastCompleted)
+	}
+	if node.Duration != time.Hour+12*time.Minute+34*time.Second {
+		t.Errorf("expected a duration of 1h12m34s, got %v", node.Duration)
+	}
+	expected := []CorruptedObject{
+		{GFID: "6a1e8b2c-4d3f-4e5a-9b6c-7d8e9f0a1b2c", Brick: "/mnt/gluster/gv_test", Path: "/archive/2019/report.pdf"},
+		{GFID: "c4d5e6f7-0a1b-4c2d-8e3f-4a5b6c7d8e9f", Brick: "/mnt/gluster/gv_test", Path: "/archive/2020/scan-0042.tiff"},
+	}
+	if !slices.Equal(node.Corrupted, expected) {
+		t.Errorf("expected %+v and got %+v", expected, node.Corrupted)
+	}
+
+	if pending := status.Nodes[1]; !pending.LastCompleted.IsZero() || len(pending.Corrupted) != 0 {
+		t.Errorf("expected node2 without a completed scrub, got %+v", pending)
+	}
+}
+
+func TestBitrotDisabled(t *testing.T) {
+	client := NewClient(staticRunner{ExitCode: 1, Stderr: []byte("Bitrot command failed : Bitrot is not enabled on volume gv_cluster\n")})
+	_, err := client.GetVolumeScrubStatus(context.Background(), "gv_cluster")
+	if !BitrotDisabled(err) {
+		t.Errorf("expected a disabled bitrot error, got %v", err)
+	}
+	if BitrotDisabled(ErrTimeout) {
+		t.Error("a timeout isn't a disabled bitrot")
+	}
+}
@@ -0,0 +1,51 @@
+package gluster
+
+import (
+	"fmt"
+	"strings"
+)
+
+// opErrno values glusterd reports in cliOutput, see glusterfs-errno.h
+const (
+	ErrnoInternal           = 30800
+	ErrnoOpNotSupported     = 30801
+	ErrnoAnotherTransaction = 30802
+	ErrnoBrickDown          = 30803
+	ErrnoNodeDown           = 30804
+	ErrnoHardLimitReached   = 30805
+	ErrnoNoVolume           = 30806
+)
+
+// CLIError is returned when the gluster CLI reports a failure, either through
+// a non zero exit code or through opRet/opErrno in its xml output
+type CLIError struct {
+	// Command is the subcommand without volume names, e.g. "volume quota list"
+	Command  string
+	Args     []string
+	ExitCode int
+	OpRet    int
+	OpErrno  int
+	OpErrstr string
+	Stderr   string
+}
+
+func (e *CLIError) Error() string {
+	msg := e.OpErrstr
+	if msg == "" {
+		msg = e.Stderr
+	}
+	if msg == "" {
+		msg = "no error message"
+	}
+	return fmt.Sprintf("gluster %v failed (exit code %v, opRet %v, opErrno %v): %v",
+		strings.Join(e.Args, " "), e.ExitCode, e.OpRet, e.OpErrno, msg)
+}
+
+// HasMessage reports whether opErrstr or stderr contains msg, ignoring case.
+// Not every failure comes with an opErrno, "quota not enabled" for example
+// is only reported as text.
+func (e *CLIError) HasMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(strings.ToLower(e.OpErrstr), msg) ||
+		strings.Contains(strings.ToLower(e.Stderr), msg)
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package gluster
+
+import "os/exec"
+
+// killProcessGroupOnCancel keeps the default behaviour of killing only the
+// gluster process, process groups are not available on this platform
+func killProcessGroupOnCancel(_ *exec.Cmd) {}
@@ -0,0 +1,151 @@
+package gluster
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// healCrawlTimeLayout is the ctime format of the crawl timestamps
+const healCrawlTimeLayout = "Mon Jan _2 15:04:05 2006"
+
+// HealCrawl is a crawl of the self-heal daemon in "gluster volume heal {volume} statistics"
+type HealCrawl struct {
+	// Type is INDEX or FULL
+	Type  string
+	Start time.Time
+	// End is zero while the crawl is in progress
+	End        time.Time
+	Healed     uint64
+	SplitBrain uint64
+	HealFailed uint64
+}
+
+// InProgress reports whether the self-heal daemon is still crawling
+func (c HealCrawl) InProgress() bool {
+	return c.End.IsZero()
+}
+
+// HealStatisticsBrick are the crawls of a brick in "gluster volume heal {volume} statistics".
+// Bricks are only reported by their index in the volume and their hostname.
+type HealStatisticsBrick struct {
+	Index    int
+	Hostname string
+	Crawls   []HealCrawl
+}
+
+// HealCountBrick is a brick in "gluster volume heal {volume} statistics heal-count"
+type HealCountBrick struct {
+	Name   string
+	Status string
+	// Entries is -1 when the brick couldn't be reached
+	Entries int
+}
+
+// GetVolumeHealStatistics executes "gluster volume heal {volume} statistics",
+// which has no xml output, and returns the crawls of the self-heal daemon of
+// every brick. The crawl timestamps are read in the Location of the client.
+func (c *Client) GetVolumeHealStatistics(ctx context.Context, volumeName string) ([]HealStatisticsBrick, error) {
+	args := []string{"volume", "heal", volumeName, "statistics"}
+	out, err := execGlusterText(ctx, c, "volume heal statistics", args...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHealStatistics(out, c.Location)
+}
+
+// GetVolumeHealCount executes "gluster volume heal {volume} statistics heal-count"
+// and returns the number of entries to be healed of every brick
+func (c *Client) GetVolumeHealCount(ctx context.Context, volumeName string) ([]HealCountBrick, error) {
+	args := []string{"volume", "heal", volumeName, "statistics", "heal-count"}
+	out, err := execGlusterText(ctx, c, "volume heal statistics heal-count", args...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHealCount(out)
+}
+
+// ParseHealStatistics parses the output of "gluster volume heal {volume} statistics",
+// the crawl timestamps are in the local time of the node, loc
+func ParseHealStatistics(out string, loc *time.Location) ([]HealStatisticsBrick, error) {
+	var bricks []HealStatisticsBrick
+	var brick *HealStatisticsBrick
+	var crawl *HealCrawl
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, _ := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, "Crawl statistics for brick no "):
+			index, err := strconv.Atoi(strings.TrimPrefix(line, "Crawl statistics for brick no "))
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse brick number of %q: %w", line, err)
+			}
+			bricks = append(bricks, HealStatisticsBrick{Index: index})
+			brick, crawl = &bricks[len(bricks)-1], nil
+		case brick == nil:
+			continue
+		case strings.HasPrefix(line, "Hostname of brick "):
+			brick.Hostname = strings.TrimPrefix(line, "Hostname of brick ")
+		case key == "Starting time of crawl":
+			brick.Crawls = append(brick.Crawls, HealCrawl{})
+			crawl = &brick.Crawls[len(brick.Crawls)-1]
+			crawl.Start, err = time.ParseInLocation(healCrawlTimeLayout, value, loc)
+		case crawl == nil:
+			continue
+		case key == "Ending time of crawl":
+			crawl.End, err = time.ParseInLocation(healCrawlTimeLayout, value, loc)
+		case key == "Type of crawl":
+			crawl.Type = value
+		case key == "No. of entries healed":
+			crawl.Healed, err = strconv.ParseUint(value, 10, 64)
+		case key == "No. of entries in split-brain":
+			crawl.SplitBrain, err = strconv.ParseUint(value, 10, 64)
+		case key == "No. of heal failed entries":
+			crawl.HealFailed, err = strconv.ParseUint(value, 10, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %q: %w", line, err)
+		}
+	}
+	return bricks, scanner.Err()
+}
+
+// ParseHealCount parses the output of "gluster volume heal {volume} statistics heal-count"
+func ParseHealCount(out string) ([]HealCountBrick, error) {
+	var bricks []HealCountBrick
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, _ := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(line, "Brick "):
+			bricks = append(bricks, HealCountBrick{Name: strings.TrimPrefix(line, "Brick "), Entries: -1})
+		case len(bricks) == 0:
+			continue
+		case key == "Status":
+			bricks[len(bricks)-1].Status = value
+		case key == "Number of entries":
+			// disconnected bricks report "-"
+			if value == "-" {
+				continue
+			}
+			entries, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse %q: %w", line, err)
+			}
+			bricks[len(bricks)-1].Entries = entries
+		}
+	}
+	return bricks, scanner.Err()
+}
@@ -0,0 +1,58 @@
+package gluster
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultVolumeOptions are the volume options exported by default, the ones
+// guarding encryption, quorum and the optional features
+var DefaultVolumeOptions = []string{
+	"client.ssl",
+	"cluster.quorum-type",
+	"cluster.server-quorum-type",
+	"features.bitrot",
+	"features.quota",
+	"features.shard",
+	"nfs.disable",
+	"server.ssl",
+}
+
+var (
+	optionTrue  = []string{"on", "yes", "true", "enable", "1"}
+	optionFalse = []string{"off", "no", "false", "disable", "0"}
+)
+
+// Option returns the value of the option name set on the volume
+func (v Volume) Option(name string) (string, bool) {
+	for _, option := range v.Options {
+		if option.Name == name {
+			return option.Value, true
+		}
+	}
+	return "", false
+}
+
+// Option returns the effective value of the option name, without the
+// "(DEFAULT)" suffix newer releases add to options left at their default
+func (x VolumeGetOptsXML) Option(name string) (string, bool) {
+	for _, opt := range x.VolGetOpts.Opt {
+		if opt.Option == name {
+			return strings.TrimSpace(strings.TrimSuffix(opt.Value, "(DEFAULT)")), true
+		}
+	}
+	return "", false
+}
+
+// OptionValueEqual reports whether two option values are the same to gluster,
+// which accepts on, yes, true, enable and 1 for booleans and ignores case
+func OptionValueEqual(x, y string) bool {
+	x, y = strings.ToLower(strings.TrimSpace(x)), strings.ToLower(strings.TrimSpace(y))
+	if slices.Contains(optionTrue, x) {
+		return slices.Contains(optionTrue, y)
+	}
+	if slices.Contains(optionFalse, x) {
+		return slices.Contains(optionFalse, y)
+	}
+	return x == y
+}
@@ -0,0 +1,7 @@
+package gluster
+
+// Types of the tasks in "gluster volume status {volume} tasks"
+const (
+	TaskRebalance   = "Rebalance"
+	TaskRemoveBrick = "Remove brick"
+)
@@ -0,0 +1,66 @@
+package gluster
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRetries is how often a command is retried on lock contention
+	DefaultRetries = 3
+	// DefaultRetryBackoff is the base delay before the first retry
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// RetryReasonAnotherTransaction is used when glusterd is busy with another transaction
+	RetryReasonAnotherTransaction = "another_transaction"
+	// RetryReasonLockingFailed is used when glusterd couldn't acquire the cluster lock
+	RetryReasonLockingFailed = "locking_failed"
+)
+
+// RetryKey identifies a retry counter by subcommand and reason
+type RetryKey struct {
+	Command string
+	Reason  string
+}
+
+// lockContention returns the retry reason when result shows that glusterd
+// refused the command because its transaction lock is held, or "" otherwise.
+// Like execGlusterXML, an xml output with a failed op status is a failure
+// even when the CLI exited with 0.
+func lockContention(result Result) string {
+	// only the status elements are needed, the rest of the output is ignored
+	var status struct {
+		XMLName xml.Name `xml:"cliOutput"`
+		OpStatus
+	}
+	_ = xml.Unmarshal(result.Stdout, &status)
+	if result.ExitCode == 0 && status.OpRet == 0 && status.OpErrno == 0 {
+		return ""
+	}
+
+	msg := strings.ToLower(status.OpErrstr + "\n" + string(result.Stderr))
+	if !bytes.Contains(result.Stdout, []byte("<cliOutput")) {
+		msg += "\n" + strings.ToLower(string(result.Stdout))
+	}
+
+	switch {
+	case status.OpErrno == ErrnoAnotherTransaction, strings.Contains(msg, "another transaction is in progress"):
+		return RetryReasonAnotherTransaction
+	case strings.Contains(msg, "locking failed"), strings.Contains(msg, "unable to acquire lock"):
+		return RetryReasonLockingFailed
+	}
+	return ""
+}
+
+// retryDelay returns a jittered exponential backoff for the given attempt,
+// attempts are counted from zero
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	ceiling := base << min(attempt, 10)
+	return ceiling/2 + rand.N(ceiling/2+1)
+}
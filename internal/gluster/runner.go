@@ -0,0 +1,250 @@
+package gluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// waitDelay bounds how long Run waits for output pipes after the command was killed
+const waitDelay = 5 * time.Second
+
+// Result holds what a gluster CLI invocation printed and how it exited
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// Runner executes a single gluster CLI invocation. A command that ran and
+// exited non zero is reported through Result.ExitCode, the error is reserved
+// for commands that couldn't be started or were stopped. Implementations stop
+// the invocation once ctx is done.
+type Runner interface {
+	Run(ctx context.Context, args ...string) (Result, error)
+}
+
+// isLocal reports whether runner talks to the glusterd of this machine.
+// Runners without a Local method are treated as remote.
+func isLocal(runner Runner) bool {
+	local, ok := runner.(interface{ Local() bool })
+	return ok && local.Local()
+}
+
+// ExecRunner runs the gluster binary on the local machine
+type ExecRunner struct {
+	Binary string
+	// Prefix is prepended to every invocation for gluster CLIs behind a
+	// wrapper, e.g. ["sudo", "-n"] or ["docker", "exec", "glusterd"]
+	Prefix []string
+	// RemoteHost queries the glusterd on another host through --remote-host
+	RemoteHost string
+	// GlusterdSocket overrides the socket of the local glusterd through --glusterd-sock
+	GlusterdSocket string
+}
+
+// NewExecRunner returns an ExecRunner for the given gluster binary
+func NewExecRunner(binary string) *ExecRunner {
+	return &ExecRunner{Binary: binary}
+}
+
+// Executable returns the program that is started, the wrapper when a Prefix is set
+func (r *ExecRunner) Executable() string {
+	if len(r.Prefix) > 0 {
+		return r.Prefix[0]
+	}
+	return r.Binary
+}
+
+// Local reports whether the runner talks to the glusterd of this machine
+func (r *ExecRunner) Local() bool {
+	return r.RemoteHost == ""
+}
+
+// Run executes the gluster binary with args. When ctx is done the whole
+// process group is killed, so helpers spawned by the CLI don't linger.
+func (r *ExecRunner) Run(ctx context.Context, args ...string) (Result, error) {
+	stdoutBuffer := &bytes.Buffer{}
+	stderrBuffer := &bytes.Buffer{}
+	argv := append(append([]string{}, r.Prefix...), r.Binary)
+	if r.RemoteHost != "" {
+		argv = append(argv, "--remote-host="+r.RemoteHost)
+	}
+	if r.GlusterdSocket != "" {
+		argv = append(argv, "--glusterd-sock="+r.GlusterdSocket)
+	}
+	argv = append(argv, args...)
+	glusterExec := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	glusterExec.Stdout = stdoutBuffer
+	glusterExec.Stderr = stderrBuffer
+	glusterExec.WaitDelay = waitDelay
+	killProcessGroupOnCancel(glusterExec)
+	err := glusterExec.Run()
+
+	result := Result{Stdout: stdoutBuffer.Bytes(), Stderr: stderrBuffer.Bytes()}
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		return result, ctxErr
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	return result, err
+}
+
+// FixtureRunner answers gluster commands from XML files in Dir or in a bundle
+// written by WriteBundle. The file for "volume status all detail --xml" is
+// gluster_volume_status_all_detail.xml, flags are left out of the name.
+type FixtureRunner struct {
+	Dir string
+
+	// bundle holds the files of a bundle, nil when reading from Dir
+	bundle map[string][]byte
+	// calls holds the recorded exit codes and stderr by joined args
+	calls map[string]BundleCall
+}
+
+// NewFixtureRunner returns a FixtureRunner reading from dir
+func NewFixtureRunner(dir string) *FixtureRunner {
+	return &FixtureRunner{Dir: dir}
+}
+
+// Local reports false, fixtures never describe this machine
+func (r *FixtureRunner) Local() bool {
+	return false
+}
+
+// Run reads the fixture matching args. For per volume commands like
+// "volume heal gv_test info" a generic gluster_volume_heal_info.xml is used
+// when no volume specific file exists. Recorded calls are replayed with their
+// exit code and stderr.
+func (r *FixtureRunner) Run(ctx context.Context, args ...string) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	if call, ok := r.calls[strings.Join(args, " ")]; ok {
+		if call.Error != "" {
+			return Result{}, errors.New(call.Error)
+		}
+		dat, err := r.readFile(call.File)
+		return Result{Stdout: dat, Stderr: []byte(call.Stderr), ExitCode: call.ExitCode}, err
+	}
+
+	words := commandWords(args)
+	candidates := []string{FixtureName(args)}
+	if len(words) > 3 && words[0] == "volume" {
+		generic := append(append([]string{}, words[:2]...), words[3:]...)
+		candidates = append(candidates, fixtureName(generic, fixtureExt(args)))
+	}
+
+	for _, name := range candidates {
+		dat, err := r.readFile(name)
+		if err == nil {
+			return Result{Stdout: dat}, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return Result{}, err
+		}
+	}
+	return Result{}, fmt.Errorf("no fixture for %v in %v, tried %v", words, r.Dir, candidates)
+}
+
+func (r *FixtureRunner) readFile(name string) ([]byte, error) {
+	if r.bundle == nil {
+		return os.ReadFile(filepath.Join(r.Dir, name))
+	}
+	dat, ok := r.bundle[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return dat, nil
+}
+
+// FixtureName returns the name of the file answering the gluster command args,
+// .xml for commands run with --xml and .txt for the plain text ones
+func FixtureName(args []string) string {
+	return fixtureName(commandWords(args), fixtureExt(args))
+}
+
+var fixtureNameReplacer = strings.NewReplacer("/", "_", ":", "_")
+
+func fixtureName(words []string, ext string) string {
+	// bricks like host:/path are arguments of remove-brick, neither character
+	// is allowed in file names of module zips or on windows
+	return "gluster_" + fixtureNameReplacer.Replace(strings.Join(words, "_")) + ext
+}
+
+func fixtureExt(args []string) string {
+	if slices.Contains(args, "--xml") {
+		return ".xml"
+	}
+	return ".txt"
+}
+
+// commandWords returns args without flags like --xml
+func commandWords(args []string) []string {
+	words := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			words = append(words, arg)
+		}
+	}
+	return words
+}
+
+// Call is a single invocation captured by a RecordingRunner
+type Call struct {
+	Args   []string
+	Result Result
+	Err    error
+}
+
+// RecordingRunner wraps another Runner and keeps every call it forwards
+type RecordingRunner struct {
+	Runner Runner
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecordingRunner returns a RecordingRunner forwarding to runner
+func NewRecordingRunner(runner Runner) *RecordingRunner {
+	return &RecordingRunner{Runner: runner}
+}
+
+// Local reports whether the wrapped Runner is local
+func (r *RecordingRunner) Local() bool {
+	return isLocal(r.Runner)
+}
+
+// Run forwards args to the wrapped Runner and records the result
+func (r *RecordingRunner) Run(ctx context.Context, args ...string) (Result, error) {
+	result, err := r.Runner.Run(ctx, args...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{
+		Args:   append([]string{}, args...),
+		Result: result,
+		Err:    err,
+	})
+	return result, err
+}
+
+// Calls returns a copy of the recorded calls in the order they were made
+func (r *RecordingRunner) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call{}, r.calls...)
+}
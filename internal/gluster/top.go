@@ -0,0 +1,33 @@
+package gluster
+
+import "slices"
+
+// Operations of "gluster volume top"
+const (
+	TopOpen      = "open"
+	TopRead      = "read"
+	TopWrite     = "write"
+	TopOpendir   = "opendir"
+	TopReaddir   = "readdir"
+	TopReadPerf  = "read-perf"
+	TopWritePerf = "write-perf"
+)
+
+// MaxTopListCount is the highest list-cnt gluster accepts for "gluster volume top"
+const MaxTopListCount = 100
+
+// TopOps are the counting operations of "gluster volume top"
+var TopOps = []string{TopOpen, TopRead, TopWrite, TopOpendir, TopReaddir}
+
+// TopPerfOps are the throughput operations of "gluster volume top"
+var TopPerfOps = []string{TopReadPerf, TopWritePerf}
+
+// IsTopOp reports whether op is an operation of "gluster volume top"
+func IsTopOp(op string) bool {
+	return slices.Contains(TopOps, op) || IsTopPerfOp(op)
+}
+
+// IsTopPerfOp reports whether op reports throughput instead of counts
+func IsTopPerfOp(op string) bool {
+	return slices.Contains(TopPerfOps, op)
+}
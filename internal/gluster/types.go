@@ -4,13 +4,22 @@ import (
 	"encoding/xml"
 )
 
+// OpStatus holds the opRet, opErrno and opErrstr elements of every cliOutput
+type OpStatus struct {
+	OpRet    int    `xml:"opRet"`
+	OpErrno  int    `xml:"opErrno"`
+	OpErrstr string `xml:"opErrstr"`
+}
+
+func (s OpStatus) opStatus() OpStatus {
+	return s
+}
+
 // VolumeInfoXML struct represents cliOutput element of "gluster volume info" command
 type VolumeInfoXML struct {
-	XMLName  xml.Name `xml:"cliOutput"`
-	OpRet    int      `xml:"opRet"`
-	OpErrno  int      `xml:"opErrno"`
-	OpErrstr string   `xml:"opErrstr"`
-	VolInfo  VolInfo  `xml:"volInfo"`
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolInfo VolInfo `xml:"volInfo"`
 }
 
 // VolInfo element of "gluster volume info" command
@@ -26,16 +35,47 @@ type Volumes struct {
 	Count   int      `xml:"count"`
 }
 
-// Volume element of "gluster volume info" command
+// Volume element of "gluster volume info" command. DistCount is the number of
+// bricks per distribute subvolume, Transport is 0 for tcp, 1 for rdma and 2
+// for both and Options are only the options set on the volume, not the defaults.
 type Volume struct {
-	XMLName    xml.Name `xml:"volume"`
-	Name       string   `xml:"name"`
-	ID         string   `xml:"id"`
-	Status     int      `xml:"status"`
-	StatusStr  string   `xml:"statusStr"`
-	BrickCount int      `xml:"brickCount"`
-	Bricks     []Brick  `xml:"bricks"`
-	DistCount  int      `xml:"distCount"`
+	XMLName         xml.Name       `xml:"volume"`
+	Name            string         `xml:"name"`
+	ID              string         `xml:"id"`
+	Status          int            `xml:"status"`
+	StatusStr       string         `xml:"statusStr"`
+	SnapshotCount   int            `xml:"snapshotCount"`
+	BrickCount      int            `xml:"brickCount"`
+	Bricks          []Brick        `xml:"bricks"`
+	DistCount       int            `xml:"distCount"`
+	StripeCount     int            `xml:"stripeCount"`
+	ReplicaCount    int            `xml:"replicaCount"`
+	ArbiterCount    int            `xml:"arbiterCount"`
+	DisperseCount   int            `xml:"disperseCount"`
+	RedundancyCount int            `xml:"redundancyCount"`
+	Type            int            `xml:"type"`
+	TypeStr         string         `xml:"typeStr"`
+	Transport       int            `xml:"transport"`
+	Options         []VolumeOption `xml:"options>option"`
+}
+
+// VolumeOption is an option of a volume in "gluster volume info"
+type VolumeOption struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
+}
+
+// VolumeGetOptsXML XML type of "gluster volume get {volume} all"
+type VolumeGetOptsXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolGetOpts struct {
+		Count int `xml:"count"`
+		Opt   []struct {
+			Option string `xml:"Option"`
+			Value  string `xml:"Value"`
+		} `xml:"Opt"`
+	} `xml:"volGetopts"`
 }
 
 // Brick element of "gluster volume info" command
@@ -48,11 +88,9 @@ type Brick struct {
 
 // VolumeListXML struct represents cliOutput element of "gluster volume list" command
 type VolumeListXML struct {
-	XMLName  xml.Name `xml:"cliOutput"`
-	OpRet    int      `xml:"opRet"`
-	OpErrno  int      `xml:"opErrno"`
-	OpErrstr string   `xml:"opErrstr"`
-	VolList  VolList  `xml:"volList"`
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolList VolList `xml:"volList"`
 }
 
 // VolList element of "gluster volume list" command
@@ -63,10 +101,8 @@ type VolList struct {
 
 // PeerStatusXML struct represents cliOutput element of "gluster peer status" command
 type PeerStatusXML struct {
-	XMLName    xml.Name   `xml:"cliOutput"`
-	OpRet      int        `xml:"opRet"`
-	OpErrno    int        `xml:"opErrno"`
-	OpErrstr   string     `xml:"opErrstr"`
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
 	PeerStatus PeerStatus `xml:"peerStatus"`
 }
 
@@ -94,10 +130,8 @@ type Hostnames struct {
 
 // VolumeProfileXML struct represents cliOutput element of "gluster volume {volume} profile" command
 type VolumeProfileXML struct {
-	XMLName    xml.Name   `xml:"cliOutput"`
-	OpRet      int        `xml:"opRet"`
-	OpErrno    int        `xml:"opErrno"`
-	OpErrstr   string     `xml:"opErrstr"`
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
 	VolProfile VolProfile `xml:"volProfile"`
 }
 
@@ -137,14 +171,30 @@ type Fop struct {
 	MaxLatency float64 `xml:"maxLatency"`
 }
 
-// HealInfoBrick is a struct of HealInfoBricks
+// HealInfoFile is an entry of a brick in "gluster volume heal {volume} info",
+// Path is <gfid:...> when the brick can't resolve the gfid to a path
+type HealInfoFile struct {
+	GFID string `xml:"gfid,attr"`
+	Path string `xml:",chardata"`
+}
+
+// HealInfoBrick is a struct of HealInfoBricks. The counts are "-" when the
+// brick isn't connected, the summary counts are only set by "heal info summary".
 type HealInfoBrick struct {
-	XMLName         xml.Name `xml:"brick"`
-	Name            string   `xml:"name"`
-	Status          string   `xml:"status"`
-	NumberOfEntries string   `xml:"numberOfEntries"`
+	XMLName                        xml.Name       `xml:"brick"`
+	Name                           string         `xml:"name"`
+	File                           []HealInfoFile `xml:"file"`
+	Status                         string         `xml:"status"`
+	NumberOfEntries                string         `xml:"numberOfEntries"`
+	TotalNumberOfEntries           string         `xml:"totalNumberOfEntries"`
+	NumberOfEntriesInHealPending   string         `xml:"numberOfEntriesInHealPending"`
+	NumberOfEntriesInSplitBrain    string         `xml:"numberOfEntriesInSplitBrain"`
+	NumberOfEntriesPossiblyHealing string         `xml:"numberOfEntriesPossiblyHealing"`
 }
 
+// HealBrickConnected is the status of a brick heal info could reach
+const HealBrickConnected = "Connected"
+
 // HealInfoBricks is a struct of HealInfo
 type HealInfoBricks struct {
 	XMLName xml.Name        `xml:"bricks"`
@@ -159,19 +209,15 @@ type HealInfo struct {
 
 // VolumeHealInfoXML struct represents cliOutput element of "gluster volume {volume} heal info" command
 type VolumeHealInfoXML struct {
-	XMLName  xml.Name `xml:"cliOutput"`
-	OpRet    int      `xml:"opRet"`
-	OpErrno  int      `xml:"opErrno"`
-	OpErrstr string   `xml:"opErrstr"`
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
 	HealInfo HealInfo `xml:"healInfo"`
 }
 
 // VolumeStatusXML XML type of "gluster volume status"
 type VolumeStatusXML struct {
-	XMLName   xml.Name `xml:"cliOutput"`
-	OpRet     int      `xml:"opRet"`
-	OpErrno   int      `xml:"opErrno"`
-	OpErrstr  string   `xml:"opErrstr"`
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
 	VolStatus struct {
 		Volumes struct {
 			Volume []struct {
@@ -182,9 +228,10 @@ type VolumeStatusXML struct {
 					Path     string `xml:"path"`
 					PeerID   string `xml:"peerid"`
 					Status   int    `xml:"status"`
-					Port     int    `xml:"port"`
-					Ports    struct {
-						TCP  int    `xml:"tcp"`
+					// Port and Ports are N/A for offline bricks
+					Port  string `xml:"port"`
+					Ports struct {
+						TCP  string `xml:"tcp"`
 						RDMA string `xml:"rdma"`
 					} `xml:"ports"`
 					Pid        int    `xml:"pid"`
@@ -224,9 +271,401 @@ type VolQuota struct {
 
 // VolumeQuotaXML XML type of "gluster volume quota list"
 type VolumeQuotaXML struct {
-	XMLName  xml.Name `xml:"cliOutput"`
-	OpRet    int      `xml:"opRet"`
-	OpErrno  int      `xml:"opErrno"`
-	OpErrstr string   `xml:"opErrstr"`
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
 	VolQuota VolQuota `xml:"volQuota"`
 }
+
+// StatusClient is a client connection of a brick in "gluster volume status {volume} clients"
+type StatusClient struct {
+	Hostname   string `xml:"hostname"`
+	BytesRead  uint64 `xml:"bytesRead"`
+	BytesWrite uint64 `xml:"bytesWrite"`
+	OpVersion  int    `xml:"opVersion"`
+}
+
+// VolumeStatusClientsXML XML type of "gluster volume status {volume} clients"
+type VolumeStatusClientsXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolStatus struct {
+		Volumes struct {
+			Volume []struct {
+				VolName   string `xml:"volName"`
+				NodeCount int    `xml:"nodeCount"`
+				Node      []struct {
+					Hostname      string `xml:"hostname"`
+					Path          string `xml:"path"`
+					PeerID        string `xml:"peerid"`
+					Status        int    `xml:"status"`
+					ClientsStatus struct {
+						ClientCount int            `xml:"clientCount"`
+						Client      []StatusClient `xml:"client"`
+					} `xml:"clientsStatus"`
+				} `xml:"node"`
+			} `xml:"volume"`
+		} `xml:"volumes"`
+	} `xml:"volStatus"`
+}
+
+// Mallinfo is the glibc malloc state of a brick process in "gluster volume status {volume} mem"
+type Mallinfo struct {
+	Arena    uint64 `xml:"arena"`
+	Ordblks  uint64 `xml:"ordblks"`
+	Smblks   uint64 `xml:"smblks"`
+	Hblks    uint64 `xml:"hblks"`
+	Hblkhd   uint64 `xml:"hblkhd"`
+	Usmblks  uint64 `xml:"usmblks"`
+	Fsmblks  uint64 `xml:"fsmblks"`
+	Uordblks uint64 `xml:"uordblks"`
+	Fordblks uint64 `xml:"fordblks"`
+	Keepcost uint64 `xml:"keepcost"`
+}
+
+// Mempool is a memory pool of a brick process in "gluster volume status {volume} mem"
+type Mempool struct {
+	Name         string `xml:"name"`
+	HotCount     uint64 `xml:"hotcount"`
+	ColdCount    uint64 `xml:"coldcount"`
+	PaddedSizeOf uint64 `xml:"padddedSizeOf"`
+	AllocCount   uint64 `xml:"allocCount"`
+	MaxAlloc     uint64 `xml:"maxAlloc"`
+	PoolMisses   uint64 `xml:"poolMisses"`
+	MaxStdAlloc  uint64 `xml:"maxStdAlloc"`
+}
+
+// VolumeStatusMemXML XML type of "gluster volume status {volume} mem"
+type VolumeStatusMemXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolStatus struct {
+		Volumes struct {
+			Volume []struct {
+				VolName   string `xml:"volName"`
+				NodeCount int    `xml:"nodeCount"`
+				Node      []struct {
+					Hostname  string `xml:"hostname"`
+					Path      string `xml:"path"`
+					PeerID    string `xml:"peerid"`
+					Status    int    `xml:"status"`
+					MemStatus struct {
+						Mallinfo Mallinfo `xml:"mallinfo"`
+						Mempool  struct {
+							Count int       `xml:"count"`
+							Pool  []Mempool `xml:"pool"`
+						} `xml:"mempool"`
+					} `xml:"memStatus"`
+				} `xml:"node"`
+			} `xml:"volume"`
+		} `xml:"volumes"`
+	} `xml:"volStatus"`
+}
+
+// StatusFd is an open file descriptor of a client connection in "gluster volume status {volume} fd"
+type StatusFd struct {
+	Entry    int    `xml:"entry"`
+	Pid      int    `xml:"pid"`
+	RefCount int    `xml:"refCount"`
+	Flags    string `xml:"flags"`
+}
+
+// VolumeStatusFdXML XML type of "gluster volume status {volume} fd"
+type VolumeStatusFdXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolStatus struct {
+		Volumes struct {
+			Volume []struct {
+				VolName   string `xml:"volName"`
+				NodeCount int    `xml:"nodeCount"`
+				Node      []struct {
+					Hostname string `xml:"hostname"`
+					Path     string `xml:"path"`
+					PeerID   string `xml:"peerid"`
+					Status   int    `xml:"status"`
+					FdTable  struct {
+						Connections int `xml:"connections"`
+						Connection  []struct {
+							RefCount   int        `xml:"refCount"`
+							MaxFdCount int        `xml:"maxFdCount"`
+							FirstFree  int        `xml:"firstFree"`
+							Fd         []StatusFd `xml:"fd"`
+						} `xml:"connection"`
+					} `xml:"fdTable"`
+				} `xml:"node"`
+			} `xml:"volume"`
+		} `xml:"volumes"`
+	} `xml:"volStatus"`
+}
+
+// VolumeStatusInodeXML XML type of "gluster volume status {volume} inode".
+// Only the sizes of the inode tables are read, the inodes themselves are skipped.
+type VolumeStatusInodeXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolStatus struct {
+		Volumes struct {
+			Volume []struct {
+				VolName   string `xml:"volName"`
+				NodeCount int    `xml:"nodeCount"`
+				Node      []struct {
+					Hostname    string `xml:"hostname"`
+					Path        string `xml:"path"`
+					PeerID      string `xml:"peerid"`
+					Status      int    `xml:"status"`
+					InodeTables struct {
+						Connections int `xml:"connections"`
+						Connection  []struct {
+							ActiveSize uint64 `xml:"activeSize"`
+							LruSize    uint64 `xml:"lruSize"`
+							PurgeSize  uint64 `xml:"purgeSize"`
+						} `xml:"connection"`
+					} `xml:"inodeTables"`
+				} `xml:"node"`
+			} `xml:"volume"`
+		} `xml:"volumes"`
+	} `xml:"volStatus"`
+}
+
+// CallFrame is a frame of a pending call stack in "gluster volume status {volume} callpool"
+type CallFrame struct {
+	RefCount   int    `xml:"refCount"`
+	Translator string `xml:"translator"`
+	Complete   int    `xml:"complete"`
+	Parent     string `xml:"parent"`
+	WindFrom   string `xml:"windFrom"`
+	WindTo     string `xml:"windTo"`
+	UnwindFrom string `xml:"unwindFrom"`
+	UnwindTo   string `xml:"unwindTo"`
+}
+
+// CallStack is a pending call stack of a brick in "gluster volume status {volume} callpool"
+type CallStack struct {
+	UID       int         `xml:"uid"`
+	GID       int         `xml:"gid"`
+	Pid       int         `xml:"pid"`
+	Unique    uint64      `xml:"unique"`
+	Op        string      `xml:"op"`
+	Type      int         `xml:"type"`
+	Count     int         `xml:"count"`
+	CallFrame []CallFrame `xml:"callFrame"`
+}
+
+// VolumeStatusCallpoolXML XML type of "gluster volume status {volume} callpool"
+type VolumeStatusCallpoolXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolStatus struct {
+		Volumes struct {
+			Volume []struct {
+				VolName   string `xml:"volName"`
+				NodeCount int    `xml:"nodeCount"`
+				Node      []struct {
+					Hostname string `xml:"hostname"`
+					Path     string `xml:"path"`
+					PeerID   string `xml:"peerid"`
+					Status   int    `xml:"status"`
+					Callpool struct {
+						Count     int         `xml:"count"`
+						CallStack []CallStack `xml:"callStack"`
+					} `xml:"callpool"`
+				} `xml:"node"`
+			} `xml:"volume"`
+		} `xml:"volumes"`
+	} `xml:"volStatus"`
+}
+
+// TopFile is a file of a brick in "gluster volume top". Count is set for
+// the counting operations, Throughput and Time for read-perf and write-perf.
+type TopFile struct {
+	Filename   string  `xml:"filename"`
+	Count      uint64  `xml:"count"`
+	Throughput float64 `xml:"throughput"`
+	Time       string  `xml:"time"`
+}
+
+// VolumeTopXML XML type of "gluster volume top {volume} {op}"
+type VolumeTopXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolTop struct {
+		BrickCount int `xml:"brickCount"`
+		TopOp      int `xml:"topOp"`
+		Brick      []struct {
+			Name        string    `xml:"name"`
+			Members     int       `xml:"members"`
+			CurrentOpen uint64    `xml:"currentOpen"`
+			MaxOpen     uint64    `xml:"maxOpen"`
+			MaxOpenTime string    `xml:"maxOpenTime"`
+			Throughput  float64   `xml:"throughput"`
+			TimeTaken   float64   `xml:"timeTaken"`
+			File        []TopFile `xml:"file"`
+		} `xml:"brick"`
+	} `xml:"volTop"`
+}
+
+// GeoRepPair is a worker of a geo-replication session in "gluster volume geo-replication status detail".
+// Gluster 8 renamed master and slave to primary and secondary, GetGeoRepStatusDetail
+// copies the new names into the Master and Slave fields.
+type GeoRepPair struct {
+	MasterNode               string `xml:"master_node"`
+	MasterBrick              string `xml:"master_brick"`
+	SlaveUser                string `xml:"slave_user"`
+	Slave                    string `xml:"slave"`
+	SlaveNode                string `xml:"slave_node"`
+	PrimaryNode              string `xml:"primary_node"`
+	PrimaryBrick             string `xml:"primary_brick"`
+	SecondaryUser            string `xml:"secondary_user"`
+	Secondary                string `xml:"secondary"`
+	SecondaryNode            string `xml:"secondary_node"`
+	Status                   string `xml:"status"`
+	CrawlStatus              string `xml:"crawl_status"`
+	Entry                    string `xml:"entry"`
+	Data                     string `xml:"data"`
+	Meta                     string `xml:"meta"`
+	Failures                 string `xml:"failures"`
+	CheckpointCompleted      string `xml:"checkpoint_completed"`
+	LastSynced               string `xml:"last_synced"`
+	CheckpointTime           string `xml:"checkpoint_time"`
+	CheckpointCompletionTime string `xml:"checkpoint_completion_time"`
+}
+
+// VolumeGeoRepStatusXML XML type of "gluster volume geo-replication status detail"
+type VolumeGeoRepStatusXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	GeoRep struct {
+		Volume []struct {
+			Name     string `xml:"name"`
+			Sessions struct {
+				Session []struct {
+					SessionSlave     string       `xml:"session_slave"`
+					SessionSecondary string       `xml:"session_secondary"`
+					Pair             []GeoRepPair `xml:"pair"`
+				} `xml:"session"`
+			} `xml:"sessions"`
+		} `xml:"volume"`
+	} `xml:"geoRep"`
+}
+
+// RebalanceNode is the progress of a node in "gluster volume rebalance {volume} status"
+// and "gluster volume remove-brick {volume} {bricks} status". Files, Size and
+// Lookups are what the node migrated, moved and scanned.
+type RebalanceNode struct {
+	NodeName  string  `xml:"nodeName"`
+	ID        string  `xml:"id"`
+	Files     uint64  `xml:"files"`
+	Size      uint64  `xml:"size"`
+	Lookups   uint64  `xml:"lookups"`
+	Failures  uint64  `xml:"failures"`
+	Skipped   uint64  `xml:"skipped"`
+	Status    int     `xml:"status"`
+	StatusStr string  `xml:"statusStr"`
+	Runtime   float64 `xml:"runtime"`
+	// TimeLeft is only reported by gluster versions that estimate it
+	TimeLeft *uint64 `xml:"timeLeft"`
+}
+
+// RebalanceStatus is the progress of a rebalance or remove-brick task
+type RebalanceStatus struct {
+	TaskID    string          `xml:"task-id"`
+	Op        int             `xml:"op"`
+	NodeCount int             `xml:"nodeCount"`
+	Node      []RebalanceNode `xml:"node"`
+	Aggregate RebalanceNode   `xml:"aggregate"`
+}
+
+// VolumeRebalanceXML XML type of "gluster volume rebalance {volume} status"
+type VolumeRebalanceXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolRebalance RebalanceStatus `xml:"volRebalance"`
+}
+
+// VolumeRemoveBrickXML XML type of "gluster volume remove-brick {volume} {bricks} status"
+type VolumeRemoveBrickXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolRemoveBrick RebalanceStatus `xml:"volRemoveBrick"`
+}
+
+// VolumeTask is a task of a volume in "gluster volume status {volume} tasks"
+type VolumeTask struct {
+	Type   string `xml:"type"`
+	ID     string `xml:"id"`
+	Params struct {
+		Brick []string `xml:"brick"`
+	} `xml:"params"`
+	Status    int    `xml:"status"`
+	StatusStr string `xml:"statusStr"`
+}
+
+// VolumeStatusTasksXML XML type of "gluster volume status {volume} tasks"
+type VolumeStatusTasksXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	VolStatus struct {
+		Volumes struct {
+			Volume []struct {
+				VolName string `xml:"volName"`
+				Tasks   struct {
+					Task []VolumeTask `xml:"task"`
+				} `xml:"tasks"`
+			} `xml:"volume"`
+		} `xml:"volumes"`
+	} `xml:"volStatus"`
+}
+
+// Snapshot is a snapshot in "gluster snapshot info". CreateTime is in UTC.
+type Snapshot struct {
+	Name        string `xml:"name"`
+	UUID        string `xml:"uuid"`
+	Description string `xml:"description"`
+	CreateTime  string `xml:"createTime"`
+	VolCount    int    `xml:"volCount"`
+	SnapVolume  []struct {
+		Name string `xml:"name"`
+		// Status is Started for activated snapshots and Stopped otherwise
+		Status       string `xml:"status"`
+		OriginVolume struct {
+			Name          string `xml:"name"`
+			SnapCount     int    `xml:"snapCount"`
+			SnapRemaining int    `xml:"snapRemaining"`
+		} `xml:"originVolume"`
+	} `xml:"snapVolume"`
+}
+
+// SnapshotInfoXML XML type of "gluster snapshot info"
+type SnapshotInfoXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	SnapInfo struct {
+		Count     int `xml:"count"`
+		Snapshots struct {
+			Snapshot []Snapshot `xml:"snapshot"`
+		} `xml:"snapshots"`
+	} `xml:"snapInfo"`
+}
+
+// SnapshotConfigXML XML type of "gluster snapshot config". The system soft
+// limit is a percentage of the hard limit, the volume soft limits are counts.
+type SnapshotConfigXML struct {
+	XMLName xml.Name `xml:"cliOutput"`
+	OpStatus
+	SnapConfig struct {
+		SystemConfig struct {
+			HardLimit        int    `xml:"hardLimit"`
+			SoftLimit        string `xml:"softLimit"`
+			AutoDelete       string `xml:"autoDelete"`
+			ActivateOnCreate string `xml:"activateOnCreate"`
+		} `xml:"systemConfig"`
+		VolumeConfig struct {
+			Volume []struct {
+				Name               string `xml:"name"`
+				HardLimit          int    `xml:"hardLimit"`
+				EffectiveHardLimit int    `xml:"effectiveHardLimit"`
+				SoftLimit          int    `xml:"softLimit"`
+			} `xml:"volume"`
+		} `xml:"volumeConfig"`
+	} `xml:"snapConfig"`
+}
@@ -52,9 +52,56 @@ func TestInfoUnmarshall(t *testing.T) {
 		}
 		t.Logf("Volume.Name: %v volume.Status: %v", volume.Name, volume.Status)
 	}
+
+	gvTest := glusterVolumeInfo.VolInfo.Volumes.Volume[1]
+	if len(gvTest.Options) != 7 {
+		t.Errorf("Expected 7 options of gv_test and got %v", len(gvTest.Options))
+	}
+	if value, ok := gvTest.Option("diagnostics.count-fop-hits"); !ok || value != "on" {
+		t.Errorf("Expected diagnostics.count-fop-hits to be on, got %q", value)
+	}
+	if gvTest.TypeStr != "Replicate" || gvTest.ReplicaCount != 4 || gvTest.StripeCount != 1 || gvTest.TransportName() != "tcp" {
+		t.Errorf("Layout of gv_test doesn't match: %+v", gvTest)
+	}
 	t.Log("gluster volume info test was successful.")
 }
 
+func TestVolumeDistributeCount(t *testing.T) {
+	var tests = []struct {
+		volume   Volume
+		expected int
+	}{
+		{volume: Volume{BrickCount: 4, DistCount: 4}, expected: 1},
+		{volume: Volume{BrickCount: 6, DistCount: 3}, expected: 2},
+		{volume: Volume{BrickCount: 3, DistCount: 1}, expected: 3},
+		{volume: Volume{BrickCount: 2}, expected: 2},
+	}
+	for _, c := range tests {
+		if count := c.volume.DistributeCount(); count != c.expected {
+			t.Errorf("%v bricks with %v per subvolume: expected %v, got %v", c.volume.BrickCount, c.volume.DistCount, c.expected, count)
+		}
+	}
+}
+
+func TestOptionValueEqual(t *testing.T) {
+	var tests = []struct {
+		x, y  string
+		equal bool
+	}{
+		{x: "on", y: "on", equal: true},
+		{x: "yes", y: "on", equal: true},
+		{x: "Enable", y: "true", equal: true},
+		{x: "off", y: "on", equal: false},
+		{x: "auto", y: "auto", equal: true},
+		{x: "auto", y: "fixed", equal: false},
+	}
+	for _, c := range tests {
+		if equal := OptionValueEqual(c.x, c.y); equal != c.equal {
+			t.Errorf("%v and %v: expected %v, got %v", c.x, c.y, c.equal, equal)
+		}
+	}
+}
+
 func TestPeerStatusXMLUnmarshall(t *testing.T) {
 	testXMLPath := "../../test/gluster_peer_status.xml"
 	t.Log("Test xml unmarshal for 'gluster peer status' with file: ", testXMLPath)
@@ -270,3 +317,237 @@ func TestVolumeQuotaListXMLUnmarshall(t *testing.T) {
 	}
 
 }
+
+func TestVolumeStatusClientsXMLUnmarshall(t *testing.T) {
+	cmdOutBuffer := getCliBufferHelper("../../test/gluster_volume_status_gv_test_clients.xml")
+	clientsStatus, err := utils.DecodeXml[VolumeStatusClientsXML](cmdOutBuffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := clientsStatus.VolStatus.Volumes.Volume[0].Node
+	if len(nodes) != 2 {
+		t.Fatalf("Expected 2 bricks and got %v", len(nodes))
+	}
+	if nodes[0].ClientsStatus.ClientCount != 3 || len(nodes[0].ClientsStatus.Client) != 3 {
+		t.Errorf("Expected 3 clients on the first brick and got %v", nodes[0].ClientsStatus)
+	}
+	client := nodes[0].ClientsStatus.Client[2]
+	if client.Hostname != "10.0.0.21:1021" || client.BytesRead != 2216460 || client.BytesWrite != 940380 {
+		t.Errorf("Client doesn't match: %+v", client)
+	}
+}
+
+func TestVolumeStatusMemXMLUnmarshall(t *testing.T) {
+	cmdOutBuffer := getCliBufferHelper("../../test/gluster_volume_status_gv_test_mem.xml")
+	memStatus, err := utils.DecodeXml[VolumeStatusMemXML](cmdOutBuffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := memStatus.VolStatus.Volumes.Volume[0].Node[0]
+	if node.MemStatus.Mallinfo.Arena != 9924608 || node.MemStatus.Mallinfo.Uordblks != 7361328 {
+		t.Errorf("Mallinfo doesn't match: %+v", node.MemStatus.Mallinfo)
+	}
+	if len(node.MemStatus.Mempool.Pool) != node.MemStatus.Mempool.Count {
+		t.Fatalf("Expected %v pools and got %v", node.MemStatus.Mempool.Count, len(node.MemStatus.Mempool.Pool))
+	}
+	pool := node.MemStatus.Mempool.Pool[2]
+	if pool.Name != "gv_test-server:inode_t" || pool.HotCount != 61 || pool.PaddedSizeOf != 156 || pool.PoolMisses != 3 {
+		t.Errorf("Pool doesn't match: %+v", pool)
+	}
+}
+
+func TestVolumeStatusFdInodeXMLUnmarshall(t *testing.T) {
+	fdStatus, err := utils.DecodeXml[VolumeStatusFdXML](getCliBufferHelper("../../test/gluster_volume_status_gv_test_fd.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fdTable := fdStatus.VolStatus.Volumes.Volume[0].Node[0].FdTable
+	if fdTable.Connections != 3 || len(fdTable.Connection) != 3 {
+		t.Fatalf("Expected 3 connections and got %v", fdTable.Connections)
+	}
+	if len(fdTable.Connection[0].Fd) != 3 || fdTable.Connection[0].Fd[1].Flags != "O_RDONLY" {
+		t.Errorf("Fds of the first connection don't match: %+v", fdTable.Connection[0].Fd)
+	}
+
+	inodeStatus, err := utils.DecodeXml[VolumeStatusInodeXML](getCliBufferHelper("../../test/gluster_volume_status_gv_test_inode.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	connection := inodeStatus.VolStatus.Volumes.Volume[0].Node[0].InodeTables.Connection[0]
+	if connection.ActiveSize != 2 || connection.LruSize != 3 || connection.PurgeSize != 0 {
+		t.Errorf("Inode table doesn't match: %+v", connection)
+	}
+}
+
+func TestVolumeStatusCallpoolXMLUnmarshall(t *testing.T) {
+	callpoolStatus, err := utils.DecodeXml[VolumeStatusCallpoolXML](getCliBufferHelper("../../test/gluster_volume_status_gv_test_callpool.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := callpoolStatus.VolStatus.Volumes.Volume[0].Node
+	if nodes[0].Callpool.Count != 2 || len(nodes[0].Callpool.CallStack) != 2 {
+		t.Fatalf("Expected 2 call stacks on the first brick and got %v", nodes[0].Callpool.Count)
+	}
+	stack := nodes[0].Callpool.CallStack[1]
+	if stack.Unique != 104861 || stack.Op != "WRITE" || len(stack.CallFrame) != 3 || stack.CallFrame[1].WindTo != "gv_test-locks" {
+		t.Errorf("Call stack doesn't match: %+v", stack)
+	}
+	if len(nodes[1].Callpool.CallStack) != 0 {
+		t.Errorf("Expected no call stacks on the second brick and got %v", nodes[1].Callpool.CallStack)
+	}
+}
+
+func TestVolumeTopXMLUnmarshall(t *testing.T) {
+	volumeTop, err := utils.DecodeXml[VolumeTopXML](getCliBufferHelper("../../test/gluster_volume_top_gv_test_open_list-cnt_10.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	brick := volumeTop.VolTop.Brick[0]
+	if brick.Name != "node1.example.local:/mnt/gluster/gv_test" || brick.CurrentOpen != 12 || brick.MaxOpen != 57 {
+		t.Errorf("Brick doesn't match: %+v", brick)
+	}
+	if len(brick.File) != brick.Members || brick.File[0].Filename != "/data/db/journal.log" || brick.File[0].Count != 211 {
+		t.Errorf("Files don't match: %+v", brick.File)
+	}
+
+	volumeTop, err = utils.DecodeXml[VolumeTopXML](getCliBufferHelper("../../test/gluster_volume_top_gv_test_read-perf_list-cnt_10.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := volumeTop.VolTop.Brick[0].File[0]
+	if file.Throughput != 412.5 || file.Time != "2024-01-12 09:41:30.001200" {
+		t.Errorf("File doesn't match: %+v", file)
+	}
+}
+
+func TestVolumeHealInfoSplitBrainXMLUnmarshall(t *testing.T) {
+	healInfo, err := utils.DecodeXml[VolumeHealInfoXML](getCliBufferHelper("../../test/gluster_volume_heal_info_split-brain.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := healInfo.HealInfo.Bricks.Brick[0].File
+	if len(files) != 2 || files[0].GFID != "8c9d5a5e-6ad3-4a2b-9f5e-2b0f1f4c7d21" || files[0].Path != "/data/db/index.db" {
+		t.Errorf("Files don't match: %+v", files)
+	}
+	if files[1].Path != "<gfid:e5c164c8-a121-4286-b339-879fb743e105>" {
+		t.Errorf("Expected the unresolved gfid as path and got %v", files[1].Path)
+	}
+}
+
+func TestVolumeHealInfoSummaryXMLUnmarshall(t *testing.T) {
+	healInfo, err := utils.DecodeXml[VolumeHealInfoXML](getCliBufferHelper("../../test/gluster_volume_heal_info_summary.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	brick := healInfo.HealInfo.Bricks.Brick[0]
+	if brick.Status != HealBrickConnected || brick.TotalNumberOfEntries != "5" || brick.NumberOfEntriesInHealPending != "2" ||
+		brick.NumberOfEntriesInSplitBrain != "2" || brick.NumberOfEntriesPossiblyHealing != "1" {
+		t.Errorf("Brick doesn't match: %+v", brick)
+	}
+	if healInfo.HealInfo.Bricks.Brick[2].TotalNumberOfEntries != "-" {
+		t.Errorf("Expected no count for the disconnected brick, got %+v", healInfo.HealInfo.Bricks.Brick[2])
+	}
+}
+
+func TestVolumeGeoRepStatusXMLUnmarshall(t *testing.T) {
+	geoRep, err := utils.DecodeXml[VolumeGeoRepStatusXML](getCliBufferHelper("../../test/gluster_volume_geo-replication_status_detail.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(geoRep.GeoRep.Volume) != 2 {
+		t.Fatalf("Expected 2 volumes with sessions and got %v", len(geoRep.GeoRep.Volume))
+	}
+	pair := geoRep.GeoRep.Volume[0].Sessions.Session[0].Pair[0]
+	if pair.MasterNode != "node1.example.local" || pair.Status != "Active" || pair.Data != "340" || pair.LastSynced != "2024-01-15 10:00:00" {
+		t.Errorf("Pair doesn't match: %+v", pair)
+	}
+}
+
+func TestVolumeRebalanceXMLUnmarshall(t *testing.T) {
+	rebalance, err := utils.DecodeXml[VolumeRebalanceXML](getCliBufferHelper("../../test/gluster_volume_rebalance_gv_test_status.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rebalance.VolRebalance.Node) != 2 {
+		t.Fatalf("Expected 2 nodes and got %v", len(rebalance.VolRebalance.Node))
+	}
+	node := rebalance.VolRebalance.Node[0]
+	if node.NodeName != "localhost" || node.Files != 18230 || node.Size != 96488591360 || node.Runtime != 86412 || node.Status != 1 {
+		t.Errorf("Node doesn't match: %+v", node)
+	}
+	if node.TimeLeft == nil || *node.TimeLeft != 52000 {
+		t.Errorf("Expected 52000 seconds left, got %v", node.TimeLeft)
+	}
+}
+
+func TestVolumeRemoveBrickXMLUnmarshall(t *testing.T) {
+	removeBrick, err := utils.DecodeXml[VolumeRemoveBrickXML](getCliBufferHelper("../../test/gluster_volume_remove-brick_gv_test_host4.example.local__mnt_gluster_gv_test_status.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := removeBrick.VolRemoveBrick.Node[0]
+	if node.Status != 3 || node.TimeLeft != nil {
+		t.Errorf("Node doesn't match: %+v", node)
+	}
+}
+
+func TestVolumeStatusTasksXMLUnmarshall(t *testing.T) {
+	tasks, err := utils.DecodeXml[VolumeStatusTasksXML](getCliBufferHelper("../../test/gluster_volume_status_gv_test_tasks.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := tasks.VolStatus.Volumes.Volume[0].Tasks.Task
+	if len(task) != 2 {
+		t.Fatalf("Expected 2 tasks and got %v", len(task))
+	}
+	if task[1].Type != TaskRemoveBrick || len(task[1].Params.Brick) != 1 || task[1].Params.Brick[0] != "host4.example.local:/mnt/gluster/gv_test" {
+		t.Errorf("Task doesn't match: %+v", task[1])
+	}
+}
+
+func TestSnapshotInfoXMLUnmarshall(t *testing.T) {
+	info, err := utils.DecodeXml[SnapshotInfoXML](getCliBufferHelper("../../test/gluster_snapshot_info.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots := info.SnapInfo.Snapshots.Snapshot
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots and got %v", len(snapshots))
+	}
+	snapVolume := snapshots[1].SnapVolume[0]
+	if snapshots[1].CreateTime != "2024-01-15 02:00:00" || snapVolume.Status != "Started" || snapVolume.OriginVolume.Name != "gv_test" {
+		t.Errorf("Snapshot doesn't match: %+v", snapshots[1])
+	}
+}
+
+func TestSnapshotConfigXMLUnmarshall(t *testing.T) {
+	config, err := utils.DecodeXml[SnapshotConfigXML](getCliBufferHelper("../../test/gluster_snapshot_config.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.SnapConfig.SystemConfig.HardLimit != 256 || config.SnapConfig.SystemConfig.SoftLimit != "90%" {
+		t.Errorf("System config doesn't match: %+v", config.SnapConfig.SystemConfig)
+	}
+	volume := config.SnapConfig.VolumeConfig.Volume[1]
+	if volume.Name != "gv_test" || volume.EffectiveHardLimit != 10 || volume.SoftLimit != 9 {
+		t.Errorf("Volume config doesn't match: %+v", volume)
+	}
+}
+
+func TestGeoRepSlave(t *testing.T) {
+	var tests = []struct {
+		slave, host, volume string
+	}{
+		{slave: "ssh://geoaccount@dr1.example.com::gv_dr", host: "dr1.example.com", volume: "gv_dr"},
+		{slave: "ssh://dr1.example.com::gv_cluster_dr", host: "dr1.example.com", volume: "gv_cluster_dr"},
+		{slave: "dr1.example.com::gv_dr", host: "dr1.example.com", volume: "gv_dr"},
+	}
+	for _, c := range tests {
+		if host, volume := GeoRepSlave(c.slave); host != c.host || volume != c.volume {
+			t.Errorf("%v: expected %v and %v, got %v and %v", c.slave, c.host, c.volume, host, volume)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package gluster
+
+import "strconv"
+
+// TransportName returns the transport of the volume, tcp, rdma or tcp,rdma
+func (v Volume) TransportName() string {
+	switch v.Transport {
+	case 0:
+		return "tcp"
+	case 1:
+		return "rdma"
+	case 2:
+		return "tcp,rdma"
+	}
+	return strconv.Itoa(v.Transport)
+}
+
+// DistributeCount returns the number of distribute subvolumes of the volume
+func (v Volume) DistributeCount() int {
+	if v.DistCount == 0 {
+		return v.BrickCount
+	}
+	return v.BrickCount / v.DistCount
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"net/http"
+)
+
+type corruptedObject struct {
+	GFID  string `json:"gfid"`
+	Brick string `json:"brick,omitempty"`
+	Path  string `json:"path,omitempty"`
+}
+
+type corruptedNode struct {
+	Node      string            `json:"node"`
+	Errors    uint64            `json:"errors"`
+	Corrupted []corruptedObject `json:"corrupted"`
+}
+
+type bitrotResponse struct {
+	Volume  string          `json:"volume"`
+	Enabled bool            `json:"enabled"`
+	Nodes   []corruptedNode `json:"nodes"`
+}
+
+// Bitrot runs "gluster volume bitrot scrub status" for the volume query
+// parameter, one of volumes, and returns the GFIDs of the corrupted objects of
+// every node as JSON. Volumes without bitrot detection are answered with
+// enabled false.
+func Bitrot(client *gluster.Client, volumes []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		volume := r.URL.Query().Get("volume")
+		if !checkVolume(w, volumes, volume) {
+			return
+		}
+
+		res := bitrotResponse{Volume: volume, Nodes: []corruptedNode{}}
+		status, err := client.GetVolumeScrubStatus(r.Context(), volume)
+		if gluster.BitrotDisabled(err) {
+			writeJSON(w, http.StatusOK, res)
+			return
+		} else if err != nil {
+			writeJSONError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		res.Enabled = true
+		for _, node := range status.Nodes {
+			resNode := corruptedNode{Node: node.Node, Errors: node.ErrorCount, Corrupted: []corruptedObject{}}
+			for _, object := range node.Corrupted {
+				resNode.Corrupted = append(resNode.Corrupted, corruptedObject(object))
+			}
+			res.Nodes = append(res.Nodes, resNode)
+		}
+		writeJSON(w, http.StatusOK, res)
+	}
+}
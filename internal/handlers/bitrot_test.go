@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// bitrotDisabledRunner answers bitrot commands like volumes without bitrot detection do
+type bitrotDisabledRunner struct {
+	gluster.Runner
+}
+
+func (r bitrotDisabledRunner) Run(ctx context.Context, args ...string) (gluster.Result, error) {
+	return gluster.Result{ExitCode: 1, Stderr: []byte("Bitrot command failed : Bitrot is not enabled on volume gv_test\n")}, nil
+}
+
+func TestBitrot(t *testing.T) {
+	handler := Bitrot(gluster.NewClient(gluster.NewFixtureRunner("../../test")), []string{"gv_test"})
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/bitrot/corrupted?volume=gv/test", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected an invalid volume to be rejected, got %v", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/bitrot/corrupted?volume=gv_cluster", nil))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected a volume that isn't configured to be rejected, got %v", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/bitrot/corrupted?volume=gv_test", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 and got %v: %v", recorder.Code, recorder.Body)
+	}
+	var res bitrotResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Enabled || len(res.Nodes) != 2 || len(res.Nodes[0].Corrupted) != 2 {
+		t.Fatalf("expected 2 corrupted objects on the first node, got %+v", res)
+	}
+	if object := res.Nodes[0].Corrupted[0]; object.GFID != "6a1e8b2c-4d3f-4e5a-9b6c-7d8e9f0a1b2c" || object.Path != "/archive/2019/report.pdf" {
+		t.Errorf("object doesn't match: %+v", object)
+	}
+}
+
+func TestBitrotDisabled(t *testing.T) {
+	handler := Bitrot(gluster.NewClient(bitrotDisabledRunner{}), []string{gluster.AllVolumes})
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/bitrot/corrupted?volume=gv_test", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 and got %v: %v", recorder.Code, recorder.Body)
+	}
+	var res bitrotResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Enabled || len(res.Nodes) != 0 {
+		t.Errorf("expected a disabled volume without nodes, got %+v", res)
+	}
+}
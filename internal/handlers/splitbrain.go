@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type splitBrainFile struct {
+	Path string `json:"path,omitempty"`
+	GFID string `json:"gfid"`
+}
+
+type splitBrainBrick struct {
+	Brick   string           `json:"brick"`
+	Status  string           `json:"status"`
+	Entries *int             `json:"entries"`
+	Files   []splitBrainFile `json:"files"`
+}
+
+type splitBrainResponse struct {
+	Volume string            `json:"volume"`
+	Bricks []splitBrainBrick `json:"bricks"`
+}
+
+// SplitBrain runs "gluster volume heal info split-brain" for the volume query
+// parameter, one of volumes, and returns the paths and GFIDs in split-brain of
+// every brick as JSON. Entries is null for bricks that couldn't be reached.
+func SplitBrain(client *gluster.Client, volumes []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		volume := r.URL.Query().Get("volume")
+		if !checkVolume(w, volumes, volume) {
+			return
+		}
+
+		splitBrain, err := client.GetVolumeHealInfoSplitBrain(r.Context(), volume)
+		if err != nil {
+			writeJSONError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		res := splitBrainResponse{Volume: volume, Bricks: []splitBrainBrick{}}
+		for _, brick := range splitBrain.HealInfo.Bricks.Brick {
+			resBrick := splitBrainBrick{Brick: brick.Name, Status: brick.Status, Files: []splitBrainFile{}}
+			if entries, err := strconv.Atoi(brick.NumberOfEntries); err == nil {
+				resBrick.Entries = &entries
+			}
+			for _, file := range brick.File {
+				resFile := splitBrainFile{GFID: file.GFID}
+				// unresolved entries are reported as <gfid:...> instead of a path
+				if !strings.HasPrefix(file.Path, "<gfid:") {
+					resFile.Path = file.Path
+				}
+				resBrick.Files = append(resBrick.Files, resFile)
+			}
+			res.Bricks = append(res.Bricks, resBrick)
+		}
+		writeJSON(w, http.StatusOK, res)
+	}
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSplitBrain(t *testing.T) {
+	handler := SplitBrain(gluster.NewClient(gluster.NewFixtureRunner("../../test")), []string{"gv_test"})
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/heal/split-brain?volume=-x", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected an invalid volume to be rejected, got %v", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/heal/split-brain?volume=gv_cluster", nil))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected a volume that isn't configured to be rejected, got %v", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/heal/split-brain?volume=gv_test", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 and got %v: %v", recorder.Code, recorder.Body)
+	}
+	var res splitBrainResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	files := res.Bricks[0].Files
+	if *res.Bricks[0].Entries != 2 || len(files) != 2 {
+		t.Fatalf("expected 2 entries on the first brick, got %+v", res.Bricks[0])
+	}
+	if files[0].Path != "/data/db/index.db" || files[1].Path != "" || files[1].GFID != "e5c164c8-a121-4286-b339-879fb743e105" {
+		t.Errorf("files don't match: %+v", files)
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"net/http"
+	"strconv"
+)
+
+type topFile struct {
+	Filename                 string  `json:"filename"`
+	Count                    uint64  `json:"count,omitempty"`
+	ThroughputBytesPerSecond float64 `json:"throughputBytesPerSecond,omitempty"`
+	Time                     string  `json:"time,omitempty"`
+}
+
+type topBrick struct {
+	Brick       string    `json:"brick"`
+	CurrentOpen *uint64   `json:"currentOpen,omitempty"`
+	MaxOpen     *uint64   `json:"maxOpen,omitempty"`
+	MaxOpenTime string    `json:"maxOpenTime,omitempty"`
+	Files       []topFile `json:"files"`
+}
+
+type topResponse struct {
+	Volume    string     `json:"volume"`
+	Op        string     `json:"op"`
+	ListCount int        `json:"listCount"`
+	Bricks    []topBrick `json:"bricks"`
+}
+
+// Top runs "gluster volume top" for the volume, op and list-cnt query
+// parameters and returns the busiest files of every brick as JSON. Only
+// volumes are answered, op defaults to read and list-cnt to listCount.
+func Top(client *gluster.Client, volumes []string, listCount int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		volume := query.Get("volume")
+		if !checkVolume(w, volumes, volume) {
+			return
+		}
+
+		op := query.Get("op")
+		if op == "" {
+			op = gluster.TopRead
+		}
+		if !gluster.IsTopOp(op) {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("unknown top operation %q", op))
+			return
+		}
+
+		count := listCount
+		if value := query.Get("list-cnt"); value != "" {
+			var err error
+			if count, err = strconv.Atoi(value); err != nil || count < 1 || count > gluster.MaxTopListCount {
+				writeJSONError(w, http.StatusBadRequest, fmt.Errorf("list-cnt has to be between 1 and %v", gluster.MaxTopListCount))
+				return
+			}
+		}
+
+		volumeTop, err := client.GetVolumeTop(r.Context(), volume, op, count)
+		if err != nil {
+			writeJSONError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		res := topResponse{Volume: volume, Op: op, ListCount: count, Bricks: []topBrick{}}
+		for _, brick := range volumeTop.VolTop.Brick {
+			resBrick := topBrick{Brick: brick.Name, Files: []topFile{}}
+			if op == gluster.TopOpen {
+				resBrick.CurrentOpen, resBrick.MaxOpen, resBrick.MaxOpenTime = &brick.CurrentOpen, &brick.MaxOpen, brick.MaxOpenTime
+			}
+			for _, file := range brick.File {
+				resFile := topFile{Filename: file.Filename, Count: file.Count, Time: file.Time}
+				if gluster.IsTopPerfOp(op) {
+					resFile.ThroughputBytesPerSecond = file.Throughput * 1e6
+				}
+				resBrick.Files = append(resBrick.Files, resFile)
+			}
+			res.Bricks = append(res.Bricks, resBrick)
+		}
+		writeJSON(w, http.StatusOK, res)
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTop(t *testing.T) {
+	handler := Top(gluster.NewClient(gluster.NewFixtureRunner("../../test")), []string{"gv_test", "gv_missing"}, 10)
+
+	var tests = []struct {
+		query  string
+		status int
+	}{
+		{query: "volume=gv_test&op=open", status: http.StatusOK},
+		{query: "volume=gv_test", status: http.StatusOK},
+		{query: "volume=gv_test&op=read-perf&list-cnt=10", status: http.StatusOK},
+		{query: "volume=--remote-host=evil", status: http.StatusBadRequest},
+		{query: "volume=gv_test&op=stat", status: http.StatusBadRequest},
+		{query: "volume=gv_test&list-cnt=1000", status: http.StatusBadRequest},
+		{query: "volume=gv_missing", status: http.StatusBadGateway},
+		{query: "volume=gv_cluster", status: http.StatusForbidden},
+	}
+	for _, c := range tests {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(http.MethodGet, "/top?"+c.query, nil))
+		if recorder.Code != c.status {
+			t.Errorf("expected status %v for %v and got %v: %v", c.status, c.query, recorder.Code, recorder.Body)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/top?volume=gv_test&op=open", nil))
+	var res topResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Op != "open" || len(res.Bricks) != 2 || *res.Bricks[0].CurrentOpen != 12 || res.Bricks[0].Files[0].Count != 211 {
+		t.Errorf("response doesn't match: %+v", res)
+	}
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"net/http"
+	"regexp"
+	"slices"
+)
+
+// volumeNamePattern matches the volume names gluster allows
+var volumeNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_-]*$`)
+
+// checkVolume answers with an error and returns false unless volume is a
+// valid volume name and one of the configured volumes
+func checkVolume(w http.ResponseWriter, volumes []string, volume string) bool {
+	if !volumeNamePattern.MatchString(volume) {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid volume %q", volume))
+		return false
+	}
+	if !slices.Contains(volumes, gluster.AllVolumes) && !slices.Contains(volumes, volume) {
+		writeJSONError(w, http.StatusForbidden, fmt.Errorf("volume %q isn't configured", volume))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// bitrotMetrics are read from "gluster volume bitrot {volume} scrub status"
+type bitrotMetrics struct {
+	enabled           *prometheus.Desc
+	scrubbedFiles     *prometheus.Desc
+	skippedFiles      *prometheus.Desc
+	lastScrubComplete *prometheus.Desc
+	lastScrubDuration *prometheus.Desc
+	scrubErrors       *prometheus.Desc
+	corruptedObjects  *prometheus.Desc
+}
+
+func newBitrotMetrics() bitrotMetrics {
+	labels := []string{"volume", "node"}
+
+	return bitrotMetrics{
+		enabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bitrot_enabled"),
+			"Whether bitrot detection is enabled on the volume.",
+			[]string{"volume"}, nil,
+		),
+
+		scrubbedFiles: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bitrot_scrubbed_files"),
+			"Files the scrubber of the node checked in the last scrub.",
+			labels, nil,
+		),
+
+		skippedFiles: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bitrot_skipped_files"),
+			"Files the scrubber of the node skipped in the last scrub because they weren't signed yet.",
+			labels, nil,
+		),
+
+		lastScrubComplete: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bitrot_last_scrub_completed_timestamp_seconds"),
+			"Time the last scrub of the node completed.",
+			labels, nil,
+		),
+
+		lastScrubDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bitrot_last_scrub_duration_seconds"),
+			"Duration of the last scrub of the node.",
+			labels, nil,
+		),
+
+		scrubErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bitrot_scrub_errors"),
+			"Errors the scrubber of the node reported.",
+			labels, nil,
+		),
+
+		corruptedObjects: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "bitrot_corrupted_objects"),
+			"Objects the scrubber of the node found corrupted, their GFIDs are listed by the bitrot endpoint.",
+			labels, nil,
+		),
+	}
+}
+
+func (c bitrotMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.enabled
+	ch <- c.scrubbedFiles
+	ch <- c.skippedFiles
+	ch <- c.lastScrubComplete
+	ch <- c.lastScrubDuration
+	ch <- c.scrubErrors
+	ch <- c.corruptedObjects
+}
+
+// collectBitrot reads the scrub status of volume. Volumes without bitrot
+// detection fail the command and are only reported as disabled.
+func (m *Metrics) collectBitrot(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	status, err := m.client.GetVolumeScrubStatus(ctx, volume)
+	if gluster.BitrotDisabled(err) {
+		ch <- prometheus.MustNewConstMetric(
+			m.bitrot.enabled, prometheus.GaugeValue, 0, volume,
+		)
+		return
+	} else if err != nil {
+		zap.L().Sugar().Errorf("couldn't get scrub status of volume %v: %v", volume, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		m.bitrot.enabled, prometheus.GaugeValue, 1, volume,
+	)
+	for _, node := range status.Nodes {
+		ch <- prometheus.MustNewConstMetric(
+			m.bitrot.scrubbedFiles, prometheus.GaugeValue, float64(node.ScrubbedFiles), volume, node.Node,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.bitrot.skippedFiles, prometheus.GaugeValue, float64(node.SkippedFiles), volume, node.Node,
+		)
+		if !node.LastCompleted.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				m.bitrot.lastScrubComplete, prometheus.GaugeValue, float64(node.LastCompleted.Unix()), volume, node.Node,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.bitrot.lastScrubDuration, prometheus.GaugeValue, node.Duration.Seconds(), volume, node.Node,
+			)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			m.bitrot.scrubErrors, prometheus.GaugeValue, float64(node.ErrorCount), volume, node.Node,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.bitrot.corruptedObjects, prometheus.GaugeValue, float64(len(node.Corrupted)), volume, node.Node,
+		)
+	}
+}
@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"slices"
+	"sync"
+	"time"
+)
+
+// callpoolMetrics are read from "gluster volume status {volume} callpool"
+type callpoolMetrics struct {
+	pendingStacks  *prometheus.Desc
+	pendingFrames  *prometheus.Desc
+	oldestStackAge *prometheus.Desc
+
+	stacks *callStackTracker
+}
+
+func newCallpoolMetrics() callpoolMetrics {
+	return callpoolMetrics{
+		pendingStacks: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_callpool_pending_stacks"),
+			"Call stacks the brick hasn't answered yet.",
+			[]string{"volume", "hostname", "path"}, nil,
+		),
+
+		pendingFrames: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_callpool_pending_frames"),
+			"Frames of all call stacks the brick hasn't answered yet.",
+			[]string{"volume", "hostname", "path"}, nil,
+		),
+
+		oldestStackAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_callpool_oldest_stack_age_seconds"),
+			"Seconds since the oldest pending call stack of the brick was first seen by the exporter. "+
+				"The output has no timestamps, so this is a lower bound with the resolution of the collection interval.",
+			[]string{"volume", "hostname", "path"}, nil,
+		),
+
+		stacks: newCallStackTracker(),
+	}
+}
+
+func (c callpoolMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pendingStacks
+	ch <- c.pendingFrames
+	ch <- c.oldestStackAge
+}
+
+// collectCallpool reads the pending call stacks of the bricks of volume
+func (m *Metrics) collectCallpool(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	callpoolStatus, err := m.client.GetVolumeStatusCallpool(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get callpool of volume %v: %v", volume, err)
+		return
+	}
+
+	pending := map[callStackKey]struct{}{}
+	for _, vol := range callpoolStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			for _, stack := range node.Callpool.CallStack {
+				pending[callStackKey{hostname: node.Hostname, path: node.Path, unique: stack.Unique}] = struct{}{}
+			}
+		}
+	}
+	firstSeen, now := m.callpool.stacks.update(volume, pending)
+
+	for _, vol := range callpoolStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Status != 1 {
+				continue
+			}
+
+			frames := 0
+			oldest := now
+			for _, stack := range node.Callpool.CallStack {
+				frames += len(stack.CallFrame)
+				seen := firstSeen[callStackKey{hostname: node.Hostname, path: node.Path, unique: stack.Unique}]
+				if seen.Before(oldest) {
+					oldest = seen
+				}
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				m.callpool.pendingStacks, prometheus.GaugeValue, float64(len(node.Callpool.CallStack)), vol.VolName, node.Hostname, node.Path,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.callpool.pendingFrames, prometheus.GaugeValue, float64(frames), vol.VolName, node.Hostname, node.Path,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.callpool.oldestStackAge, prometheus.GaugeValue, now.Sub(oldest).Seconds(), vol.VolName, node.Hostname, node.Path,
+			)
+		}
+	}
+}
+
+// callStackKey identifies a call stack across collections, the unique id is
+// assigned by the brick process
+type callStackKey struct {
+	hostname string
+	path     string
+	unique   uint64
+}
+
+// callStackTracker remembers when pending call stacks were first seen, so the
+// age of stacks that stay pending can be reported
+type callStackTracker struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	firstSeen map[string]map[callStackKey]time.Time
+}
+
+func newCallStackTracker() *callStackTracker {
+	return &callStackTracker{
+		now:       time.Now,
+		firstSeen: map[string]map[callStackKey]time.Time{},
+	}
+}
+
+// update replaces the pending stacks of volume and returns when each of them
+// was first seen. Stacks that aren't pending anymore are forgotten.
+func (t *callStackTracker) update(volume string, pending map[callStackKey]struct{}) (map[callStackKey]time.Time, time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	previous := t.firstSeen[volume]
+	firstSeen := make(map[callStackKey]time.Time, len(pending))
+	for key := range pending {
+		seen, ok := previous[key]
+		if !ok {
+			seen = now
+		}
+		firstSeen[key] = seen
+	}
+	t.firstSeen[volume] = firstSeen
+	return firstSeen, now
+}
+
+// prune forgets the stacks of all volumes except the collected ones, e.g. of
+// deleted volumes or when the callpool collector was switched off
+func (t *callStackTracker) prune(collected []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for volume := range t.firstSeen {
+		if !slices.Contains(collected, volume) {
+			delete(t.firstSeen, volume)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"net"
+)
+
+// clientMetrics are read from "gluster volume status {volume} clients"
+type clientMetrics struct {
+	// perClient adds a client label to the byte gauges instead of summing them per brick
+	perClient bool
+
+	brickClients       *prometheus.Desc
+	clientBytesRead    *prometheus.Desc
+	clientBytesWritten *prometheus.Desc
+}
+
+func newClientMetrics(perClient bool) clientMetrics {
+	labels := []string{"volume", "hostname", "path"}
+	if perClient {
+		labels = append(labels, "client")
+	}
+
+	return clientMetrics{
+		perClient: perClient,
+
+		brickClients: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_clients"),
+			"Number of clients connected to the brick.",
+			[]string{"volume", "hostname", "path"}, nil,
+		),
+
+		clientBytesRead: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_client_read_bytes"),
+			"Bytes read from the client connections of the brick, summed per client host or per brick. "+
+				"It drops when a client disconnects or the brick restarts.",
+			labels, nil,
+		),
+
+		clientBytesWritten: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_client_written_bytes"),
+			"Bytes written to the client connections of the brick, summed per client host or per brick. "+
+				"It drops when a client disconnects or the brick restarts.",
+			labels, nil,
+		),
+	}
+}
+
+func (c clientMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.brickClients
+	ch <- c.clientBytesRead
+	ch <- c.clientBytesWritten
+}
+
+// collectClients reads the client connections of the bricks of volume
+func (m *Metrics) collectClients(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	clientsStatus, err := m.client.GetVolumeStatusClients(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get clients of volume %v: %v", volume, err)
+		return
+	}
+
+	for _, vol := range clientsStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			ch <- prometheus.MustNewConstMetric(
+				m.clients.brickClients, prometheus.GaugeValue, float64(node.ClientsStatus.ClientCount), vol.VolName, node.Hostname, node.Path,
+			)
+
+			// a client host has a connection per client process and port, they are summed up
+			type traffic struct{ read, written uint64 }
+			var hosts []string
+			perHost := map[string]*traffic{}
+			for _, client := range node.ClientsStatus.Client {
+				host := ""
+				if m.clients.perClient {
+					host = clientHost(client.Hostname)
+				}
+				if _, ok := perHost[host]; !ok {
+					hosts = append(hosts, host)
+					perHost[host] = &traffic{}
+				}
+				perHost[host].read += client.BytesRead
+				perHost[host].written += client.BytesWrite
+			}
+
+			for _, host := range hosts {
+				labels := []string{vol.VolName, node.Hostname, node.Path}
+				if m.clients.perClient {
+					labels = append(labels, host)
+				}
+				ch <- prometheus.MustNewConstMetric(
+					m.clients.clientBytesRead, prometheus.GaugeValue, float64(perHost[host].read), labels...,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					m.clients.clientBytesWritten, prometheus.GaugeValue, float64(perHost[host].written), labels...,
+				)
+			}
+		}
+	}
+}
+
+// clientHost strips the port of a client address like 10.0.0.11:49146
+func clientHost(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// fdMetrics are read from "gluster volume status {volume} fd". The fd tables
+// of the client connections carry no client address, only their position in
+// the output, which shifts when clients connect, so they are exported per brick
+type fdMetrics struct {
+	brickOpenFds          *prometheus.Desc
+	brickConnections      *prometheus.Desc
+	connectionOpenFdsMax  *prometheus.Desc
+	connectionMaxFdsLimit *prometheus.Desc
+}
+
+func newFdMetrics() fdMetrics {
+	labels := []string{"volume", "hostname", "path"}
+	return fdMetrics{
+		brickOpenFds: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_open_fds"),
+			"Open file descriptors of all client connections of the brick.",
+			labels, nil,
+		),
+
+		brickConnections: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_fd_connections"),
+			"Client connections with an fd table on the brick.",
+			labels, nil,
+		),
+
+		connectionOpenFdsMax: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_connection_open_fds_max"),
+			"Most open file descriptors of a single client connection of the brick.",
+			labels, nil,
+		),
+
+		connectionMaxFdsLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_connection_max_fds"),
+			"Largest fd table of a client connection of the brick.",
+			labels, nil,
+		),
+	}
+}
+
+func (c fdMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.brickOpenFds
+	ch <- c.brickConnections
+	ch <- c.connectionOpenFdsMax
+	ch <- c.connectionMaxFdsLimit
+}
+
+// collectFd reads the fd tables of the bricks of volume
+func (m *Metrics) collectFd(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	fdStatus, err := m.client.GetVolumeStatusFd(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get fd tables of volume %v: %v", volume, err)
+		return
+	}
+
+	for _, vol := range fdStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Status != 1 {
+				continue
+			}
+
+			openFds, openFdsMax, maxFds := 0, 0, 0
+			for _, connection := range node.FdTable.Connection {
+				openFds += len(connection.Fd)
+				openFdsMax = max(openFdsMax, len(connection.Fd))
+				maxFds = max(maxFds, connection.MaxFdCount)
+			}
+
+			labels := []string{vol.VolName, node.Hostname, node.Path}
+			ch <- prometheus.MustNewConstMetric(
+				m.fd.brickOpenFds, prometheus.GaugeValue, float64(openFds), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.fd.brickConnections, prometheus.GaugeValue, float64(len(node.FdTable.Connection)), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.fd.connectionOpenFdsMax, prometheus.GaugeValue, float64(openFdsMax), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.fd.connectionMaxFdsLimit, prometheus.GaugeValue, float64(maxFds), labels...,
+			)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package metrics
+
+import (
+	"context"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// geoRepTimeLayout is the format of the sync and checkpoint times, in the
+// local time of the node, see gluster.Client.Location
+const geoRepTimeLayout = "2006-01-02 15:04:05"
+
+// geoRepStatuses are the worker states that are exported with 0 when they aren't the current one
+var geoRepStatuses = []string{"Initializing...", "Created", "Active", "Passive", "Faulty", "Paused", "Stopped"}
+
+// geoRepMetrics are read from "gluster volume geo-replication status detail"
+type geoRepMetrics struct {
+	workerStatus             *prometheus.Desc
+	crawlStatus              *prometheus.Desc
+	lastSynced               *prometheus.Desc
+	lag                      *prometheus.Desc
+	pendingEntries           *prometheus.Desc
+	failures                 *prometheus.Desc
+	checkpointCompleted      *prometheus.Desc
+	checkpointTime           *prometheus.Desc
+	checkpointCompletionTime *prometheus.Desc
+
+	now func() time.Time
+}
+
+func newGeoRepMetrics() geoRepMetrics {
+	labels := []string{"master_volume", "slave_host", "slave_volume", "master_brick"}
+
+	return geoRepMetrics{
+		workerStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_worker_status"),
+			"Whether the geo-replication worker of the brick is in status.",
+			append(slices.Clip(labels), "status"), nil,
+		),
+
+		crawlStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_crawl_status"),
+			"Current crawl of the geo-replication worker of the brick, e.g. Hybrid Crawl, History Crawl or Changelog Crawl.",
+			append(slices.Clip(labels), "crawl_status"), nil,
+		),
+
+		lastSynced: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_last_synced_timestamp_seconds"),
+			"Time up to which the geo-replication worker of the brick has synced the changes to the slave.",
+			labels, nil,
+		),
+
+		lag: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_lag_seconds"),
+			"Seconds between the last synced time of the geo-replication worker of the brick and the collection.",
+			labels, nil,
+		),
+
+		pendingEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_pending"),
+			"Changes the geo-replication worker of the brick has yet to sync, by type entry, data or meta.",
+			append(slices.Clip(labels), "type"), nil,
+		),
+
+		failures: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_failures"),
+			"Changes the geo-replication worker of the brick failed to sync.",
+			labels, nil,
+		),
+
+		checkpointCompleted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_checkpoint_completed"),
+			"Whether the geo-replication worker of the brick has synced everything up to the checkpoint.",
+			labels, nil,
+		),
+
+		checkpointTime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_checkpoint_timestamp_seconds"),
+			"Time of the checkpoint of the geo-replication session.",
+			labels, nil,
+		),
+
+		checkpointCompletionTime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "georep_checkpoint_completion_timestamp_seconds"),
+			"Time the geo-replication worker of the brick reached the checkpoint.",
+			labels, nil,
+		),
+
+		now: time.Now,
+	}
+}
+
+func (c geoRepMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.workerStatus
+	ch <- c.crawlStatus
+	ch <- c.lastSynced
+	ch <- c.lag
+	ch <- c.pendingEntries
+	ch <- c.failures
+	ch <- c.checkpointCompleted
+	ch <- c.checkpointTime
+	ch <- c.checkpointCompletionTime
+}
+
+// collectGeoRep reads the workers of the geo-replication sessions of the
+// configured volumes. Values a worker doesn't report, like the counters of
+// passive workers, are N/A and skipped.
+func (m *Metrics) collectGeoRep(ctx context.Context, ch chan<- prometheus.Metric) {
+	geoRep, err := m.client.GetGeoRepStatusDetail(ctx)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get geo-replication status: %v", err)
+		return
+	}
+
+	now := m.geoRep.now()
+	for _, volume := range geoRep.GeoRep.Volume {
+		if m.volumes[0] != gluster.AllVolumes && !slices.Contains(m.volumes, volume.Name) {
+			continue
+		}
+
+		for _, session := range volume.Sessions.Session {
+			for _, pair := range session.Pair {
+				slaveHost, slaveVolume := gluster.GeoRepSlave(pair.Slave)
+				labels := []string{volume.Name, slaveHost, slaveVolume, pair.MasterNode + ":" + pair.MasterBrick}
+
+				statuses := geoRepStatuses
+				if !slices.Contains(statuses, pair.Status) {
+					statuses = append(slices.Clip(statuses), pair.Status)
+				}
+				for _, status := range statuses {
+					current := 0.0
+					if status == pair.Status {
+						current = 1
+					}
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.workerStatus, prometheus.GaugeValue, current, append(slices.Clip(labels), status)...,
+					)
+				}
+
+				if pair.CrawlStatus != "N/A" && pair.CrawlStatus != "" {
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.crawlStatus, prometheus.GaugeValue, 1, append(slices.Clip(labels), pair.CrawlStatus)...,
+					)
+				}
+
+				if lastSynced, ok := geoRepTime(pair.LastSynced, m.client.Location); ok {
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.lastSynced, prometheus.GaugeValue, float64(lastSynced.Unix()), labels...,
+					)
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.lag, prometheus.GaugeValue, now.Sub(lastSynced).Seconds(), labels...,
+					)
+				}
+
+				for _, pending := range []struct{ typ, value string }{{"entry", pair.Entry}, {"data", pair.Data}, {"meta", pair.Meta}} {
+					if value, err := strconv.ParseUint(pending.value, 10, 64); err == nil {
+						ch <- prometheus.MustNewConstMetric(
+							m.geoRep.pendingEntries, prometheus.GaugeValue, float64(value), append(slices.Clip(labels), pending.typ)...,
+						)
+					}
+				}
+
+				if failures, err := strconv.ParseUint(pair.Failures, 10, 64); err == nil {
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.failures, prometheus.GaugeValue, float64(failures), labels...,
+					)
+				}
+
+				// without a checkpoint completed is N/A
+				if checkpointTime, ok := geoRepTime(pair.CheckpointTime, m.client.Location); ok {
+					completed := 0.0
+					if pair.CheckpointCompleted == "Yes" {
+						completed = 1
+					}
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.checkpointCompleted, prometheus.GaugeValue, completed, labels...,
+					)
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.checkpointTime, prometheus.GaugeValue, float64(checkpointTime.Unix()), labels...,
+					)
+				}
+				if completionTime, ok := geoRepTime(pair.CheckpointCompletionTime, m.client.Location); ok {
+					ch <- prometheus.MustNewConstMetric(
+						m.geoRep.checkpointCompletionTime, prometheus.GaugeValue, float64(completionTime.Unix()), labels...,
+					)
+				}
+			}
+		}
+	}
+}
+
+// geoRepTime parses a time of the geo-replication status, which is N/A
+// until there is one, in the time zone of the node
+func geoRepTime(value string, location *time.Location) (time.Time, bool) {
+	t, err := time.ParseInLocation(geoRepTimeLayout, value, location)
+	return t, err == nil
+}
@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"strconv"
+	"sync/atomic"
+)
+
+// healMetrics are read from the "gluster volume heal {volume} info" variants
+type healMetrics struct {
+	entries                *prometheus.Desc
+	pendingEntries         *prometheus.Desc
+	splitBrainEntries      *prometheus.Desc
+	possiblyHealingEntries *prometheus.Desc
+	brickConnected         *prometheus.Desc
+
+	// summaryUnsupported is set once gluster answered "heal info summary"
+	// with its usage, heal info and heal info split-brain are used then
+	summaryUnsupported *atomic.Bool
+}
+
+func newHealMetrics() healMetrics {
+	return healMetrics{
+		entries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_entries"),
+			"Entries of the brick listed by heal info, pending, in split-brain and possibly healing.",
+			[]string{"volume", "brick"}, nil,
+		),
+
+		pendingEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_pending_entries"),
+			"Entries of the brick pending heal. Without heal info summary this includes the ones in split-brain.",
+			[]string{"volume", "brick"}, nil,
+		),
+
+		splitBrainEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_split_brain_entries"),
+			"Entries of the brick in split-brain, they have to be resolved manually.",
+			[]string{"volume", "brick"}, nil,
+		),
+
+		possiblyHealingEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_possibly_healing_entries"),
+			"Entries of the brick that are possibly being healed, only reported by gluster versions with heal info summary.",
+			[]string{"volume", "brick"}, nil,
+		),
+
+		brickConnected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_brick_connected"),
+			"Whether heal info could reach the brick, status is the reason reported by gluster.",
+			[]string{"volume", "brick", "status"}, nil,
+		),
+
+		summaryUnsupported: &atomic.Bool{},
+	}
+}
+
+func (c healMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.pendingEntries
+	ch <- c.splitBrainEntries
+	ch <- c.possiblyHealingEntries
+	ch <- c.brickConnected
+}
+
+// collectHealInfo reads the entries that need healing of the bricks of
+// volume. "heal info summary" is used where gluster supports it, it counts
+// the split-brain entries in the same run.
+func (m *Metrics) collectHealInfo(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	if !m.heal.summaryUnsupported.Load() {
+		summary, err := m.client.GetVolumeHealInfoSummary(ctx, volume)
+		if err == nil {
+			m.collectHealBricks(summary, volume, ch)
+			return
+		}
+
+		var cliErr *gluster.CLIError
+		if errors.As(err, &cliErr) && cliErr.HasMessage("usage:") {
+			zap.L().Sugar().Infof("gluster doesn't support heal info summary, falling back to heal info")
+			m.heal.summaryUnsupported.Store(true)
+		} else {
+			zap.L().Sugar().Errorf("couldn't get heal info summary of volume %v, falling back to heal info: %v", volume, err)
+		}
+	}
+
+	healInfo, err := m.client.GetVolumeHealInfo(ctx, volume)
+	if err == nil {
+		m.collectHealBricks(healInfo, volume, ch)
+	}
+
+	m.collectSplitBrain(ctx, volume, ch)
+}
+
+// collectHealBricks exports the per brick counts of a heal info or heal info
+// summary output. The volume total only covers the connected bricks, so it
+// doesn't disappear when a node is down.
+func (m *Metrics) collectHealBricks(healInfo gluster.VolumeHealInfoXML, volume string, ch chan<- prometheus.Metric) {
+	total := 0
+	for _, brick := range healInfo.HealInfo.Bricks.Brick {
+		connected := 0.0
+		if brick.Status == gluster.HealBrickConnected {
+			connected = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			m.heal.brickConnected, prometheus.GaugeValue, connected, volume, brick.Name, brick.Status,
+		)
+
+		// heal info only reports the total as number of entries
+		entries, pending := brick.NumberOfEntries, brick.NumberOfEntries
+		if brick.TotalNumberOfEntries != "" {
+			entries, pending = brick.TotalNumberOfEntries, brick.NumberOfEntriesInHealPending
+		}
+		// disconnected bricks report "-"
+		brickTotal, err := strconv.Atoi(entries)
+		if err != nil {
+			continue
+		}
+		total += brickTotal
+		ch <- prometheus.MustNewConstMetric(
+			m.heal.entries, prometheus.GaugeValue, float64(brickTotal), volume, brick.Name,
+		)
+
+		if pending, err := strconv.Atoi(pending); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				m.heal.pendingEntries, prometheus.GaugeValue, float64(pending), volume, brick.Name,
+			)
+		}
+
+		if splitBrain, err := strconv.Atoi(brick.NumberOfEntriesInSplitBrain); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				m.heal.splitBrainEntries, prometheus.GaugeValue, float64(splitBrain), volume, brick.Name,
+			)
+		}
+		if possiblyHealing, err := strconv.Atoi(brick.NumberOfEntriesPossiblyHealing); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				m.heal.possiblyHealingEntries, prometheus.GaugeValue, float64(possiblyHealing), volume, brick.Name,
+			)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		m.healInfoFilesCount, prometheus.GaugeValue, float64(total), volume,
+	)
+}
+
+// collectSplitBrain reads the entries in split-brain of the bricks of volume
+func (m *Metrics) collectSplitBrain(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	splitBrain, err := m.client.GetVolumeHealInfoSplitBrain(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get split-brain entries of volume %v: %v", volume, err)
+		return
+	}
+
+	for _, brick := range splitBrain.HealInfo.Bricks.Brick {
+		// disconnected bricks report "-"
+		entries, err := strconv.Atoi(brick.NumberOfEntries)
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			m.heal.splitBrainEntries, prometheus.GaugeValue, float64(entries), volume, brick.Name,
+		)
+	}
+}
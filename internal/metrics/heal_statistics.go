@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"context"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+// healStatisticsMetrics are read from "gluster volume heal {volume} statistics"
+// and "gluster volume heal {volume} statistics heal-count"
+type healStatisticsMetrics struct {
+	crawls              *prometheus.Desc
+	crawlInProgress     *prometheus.Desc
+	lastCrawlStart      *prometheus.Desc
+	lastCrawlEnd        *prometheus.Desc
+	lastCrawlHealed     *prometheus.Desc
+	lastCrawlSplitBrain *prometheus.Desc
+	lastCrawlHealFailed *prometheus.Desc
+	healCountEntries    *prometheus.Desc
+}
+
+func newHealStatisticsMetrics() healStatisticsMetrics {
+	// heal statistics only name the index and the host of a brick
+	labels := []string{"volume", "hostname", "brick_index", "type"}
+
+	return healStatisticsMetrics{
+		crawls: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_crawls"),
+			"Crawls of the self-heal daemon the brick keeps statistics for.",
+			labels, nil,
+		),
+
+		crawlInProgress: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_crawl_in_progress"),
+			"Whether the latest crawl of the self-heal daemon on the brick is still running.",
+			labels, nil,
+		),
+
+		lastCrawlStart: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_last_crawl_start_timestamp_seconds"),
+			"Start of the latest crawl of the self-heal daemon on the brick.",
+			labels, nil,
+		),
+
+		lastCrawlEnd: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_last_crawl_end_timestamp_seconds"),
+			"End of the latest finished crawl of the self-heal daemon on the brick.",
+			labels, nil,
+		),
+
+		lastCrawlHealed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_last_crawl_healed_entries"),
+			"Entries healed by the latest crawl of the self-heal daemon on the brick.",
+			labels, nil,
+		),
+
+		lastCrawlSplitBrain: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_last_crawl_split_brain_entries"),
+			"Entries in split-brain found by the latest crawl of the self-heal daemon on the brick.",
+			labels, nil,
+		),
+
+		lastCrawlHealFailed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_last_crawl_failed_entries"),
+			"Entries the latest crawl of the self-heal daemon on the brick failed to heal.",
+			labels, nil,
+		),
+
+		healCountEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "heal_count_entries"),
+			"Entries of the brick to be healed as counted by heal statistics heal-count.",
+			[]string{"volume", "brick"}, nil,
+		),
+	}
+}
+
+func (c healStatisticsMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.crawls
+	ch <- c.crawlInProgress
+	ch <- c.lastCrawlStart
+	ch <- c.lastCrawlEnd
+	ch <- c.lastCrawlHealed
+	ch <- c.lastCrawlSplitBrain
+	ch <- c.lastCrawlHealFailed
+	ch <- c.healCountEntries
+}
+
+// collectHealStatistics reads the crawls of the self-heal daemon and the heal
+// counts of the bricks of volume
+func (m *Metrics) collectHealStatistics(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	bricks, err := m.client.GetVolumeHealStatistics(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get heal statistics of volume %v: %v", volume, err)
+	}
+
+	for _, brick := range bricks {
+		// crawls are listed oldest first, keep the latest one and the latest finished one per type
+		type crawlType struct {
+			count    int
+			latest   gluster.HealCrawl
+			finished *gluster.HealCrawl
+		}
+		var types []string
+		perType := map[string]*crawlType{}
+		for _, crawl := range brick.Crawls {
+			if _, ok := perType[crawl.Type]; !ok {
+				types = append(types, crawl.Type)
+				perType[crawl.Type] = &crawlType{}
+			}
+			stats := perType[crawl.Type]
+			stats.count++
+			if !crawl.Start.Before(stats.latest.Start) {
+				stats.latest = crawl
+			}
+			if !crawl.InProgress() && (stats.finished == nil || !crawl.End.Before(stats.finished.End)) {
+				stats.finished = &crawl
+			}
+		}
+
+		for _, typ := range types {
+			stats := perType[typ]
+			labels := []string{volume, brick.Hostname, strconv.Itoa(brick.Index), typ}
+			inProgress := 0.0
+			if stats.latest.InProgress() {
+				inProgress = 1
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				m.healStatistics.crawls, prometheus.GaugeValue, float64(stats.count), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.healStatistics.crawlInProgress, prometheus.GaugeValue, inProgress, labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.healStatistics.lastCrawlStart, prometheus.GaugeValue, float64(stats.latest.Start.Unix()), labels...,
+			)
+			if stats.finished != nil {
+				ch <- prometheus.MustNewConstMetric(
+					m.healStatistics.lastCrawlEnd, prometheus.GaugeValue, float64(stats.finished.End.Unix()), labels...,
+				)
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.healStatistics.lastCrawlHealed, prometheus.GaugeValue, float64(stats.latest.Healed), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.healStatistics.lastCrawlSplitBrain, prometheus.GaugeValue, float64(stats.latest.SplitBrain), labels...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.healStatistics.lastCrawlHealFailed, prometheus.GaugeValue, float64(stats.latest.HealFailed), labels...,
+			)
+		}
+	}
+
+	healCount, err := m.client.GetVolumeHealCount(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get heal count of volume %v: %v", volume, err)
+		return
+	}
+	for _, brick := range healCount {
+		if brick.Entries < 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			m.healStatistics.healCountEntries, prometheus.GaugeValue, float64(brick.Entries), volume, brick.Name,
+		)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// inodeMetrics are read from "gluster volume status {volume} inode"
+type inodeMetrics struct {
+	inodesActive *prometheus.Desc
+	inodesLru    *prometheus.Desc
+	inodesPurge  *prometheus.Desc
+}
+
+func newInodeMetrics() inodeMetrics {
+	return inodeMetrics{
+		inodesActive: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_inode_table_active"),
+			"Inodes in the active list of the inode tables of the brick.",
+			[]string{"volume", "hostname", "path"}, nil,
+		),
+
+		inodesLru: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_inode_table_lru"),
+			"Inodes in the LRU list of the inode tables of the brick.",
+			[]string{"volume", "hostname", "path"}, nil,
+		),
+
+		inodesPurge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_inode_table_purge"),
+			"Inodes waiting to be purged from the inode tables of the brick.",
+			[]string{"volume", "hostname", "path"}, nil,
+		),
+	}
+}
+
+func (c inodeMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.inodesActive
+	ch <- c.inodesLru
+	ch <- c.inodesPurge
+}
+
+// collectInode reads the inode table sizes of the bricks of volume
+func (m *Metrics) collectInode(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	inodeStatus, err := m.client.GetVolumeStatusInode(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get inode tables of volume %v: %v", volume, err)
+		return
+	}
+
+	for _, vol := range inodeStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Status != 1 {
+				continue
+			}
+
+			var active, lru, purge uint64
+			for _, connection := range node.InodeTables.Connection {
+				active += connection.ActiveSize
+				lru += connection.LruSize
+				purge += connection.PurgeSize
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				m.inode.inodesActive, prometheus.GaugeValue, float64(active), vol.VolName, node.Hostname, node.Path,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.inode.inodesLru, prometheus.GaugeValue, float64(lru), vol.VolName, node.Hostname, node.Path,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.inode.inodesPurge, prometheus.GaugeValue, float64(purge), vol.VolName, node.Hostname, node.Path,
+			)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// mallinfoField maps a field of the mallinfo of a brick process to a gauge
+type mallinfoField struct {
+	desc  *prometheus.Desc
+	value func(gluster.Mallinfo) uint64
+}
+
+// memMetrics are read from "gluster volume status {volume} mem"
+type memMetrics struct {
+	mallinfo []mallinfoField
+
+	mempoolHot         *prometheus.Desc
+	mempoolCold        *prometheus.Desc
+	mempoolPaddedSize  *prometheus.Desc
+	mempoolAllocations *prometheus.Desc
+	mempoolMaxAlloc    *prometheus.Desc
+	mempoolMisses      *prometheus.Desc
+}
+
+func newMemMetrics() memMetrics {
+	labels := []string{"hostname", "path", "volume"}
+	poolLabels := []string{"hostname", "path", "volume", "pool"}
+
+	mallinfo := func(name, help string, value func(gluster.Mallinfo) uint64) mallinfoField {
+		return mallinfoField{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "", "node_mallinfo_"+name),
+				help, labels, nil,
+			),
+			value: value,
+		}
+	}
+
+	return memMetrics{
+		mallinfo: []mallinfoField{
+			mallinfo("arena_bytes", "Bytes of non-mmapped space allocated by malloc in the brick process (arena).",
+				func(m gluster.Mallinfo) uint64 { return m.Arena }),
+			mallinfo("ordblks", "Number of free chunks in the brick process (ordblks).",
+				func(m gluster.Mallinfo) uint64 { return m.Ordblks }),
+			mallinfo("smblks", "Number of free fastbin blocks in the brick process (smblks).",
+				func(m gluster.Mallinfo) uint64 { return m.Smblks }),
+			mallinfo("hblks", "Number of mmapped regions of the brick process (hblks).",
+				func(m gluster.Mallinfo) uint64 { return m.Hblks }),
+			mallinfo("hblkhd_bytes", "Bytes in mmapped regions of the brick process (hblkhd).",
+				func(m gluster.Mallinfo) uint64 { return m.Hblkhd }),
+			mallinfo("usmblks_bytes", "Highwater mark of allocated space of the brick process (usmblks).",
+				func(m gluster.Mallinfo) uint64 { return m.Usmblks }),
+			mallinfo("fsmblks_bytes", "Bytes in free fastbin blocks of the brick process (fsmblks).",
+				func(m gluster.Mallinfo) uint64 { return m.Fsmblks }),
+			mallinfo("uordblks_bytes", "Bytes allocated by malloc in the brick process (uordblks).",
+				func(m gluster.Mallinfo) uint64 { return m.Uordblks }),
+			mallinfo("fordblks_bytes", "Bytes in free chunks of the brick process (fordblks).",
+				func(m gluster.Mallinfo) uint64 { return m.Fordblks }),
+			mallinfo("keepcost_bytes", "Releasable free space at the top of the heap of the brick process (keepcost).",
+				func(m gluster.Mallinfo) uint64 { return m.Keepcost }),
+		},
+
+		mempoolHot: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "node_mempool_hot_count"),
+			"Objects of the memory pool in use by the brick process.",
+			poolLabels, nil,
+		),
+
+		mempoolCold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "node_mempool_cold_count"),
+			"Objects of the memory pool allocated but not in use by the brick process.",
+			poolLabels, nil,
+		),
+
+		mempoolPaddedSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "node_mempool_padded_size_bytes"),
+			"Size of a single object of the memory pool including padding.",
+			poolLabels, nil,
+		),
+
+		mempoolAllocations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "node_mempool_allocations_total"),
+			"Objects handed out by the memory pool.",
+			poolLabels, nil,
+		),
+
+		mempoolMaxAlloc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "node_mempool_max_alloc"),
+			"Maximum number of objects of the memory pool in use at the same time.",
+			poolLabels, nil,
+		),
+
+		mempoolMisses: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "node_mempool_misses_total"),
+			"Allocations the memory pool couldn't serve, which fell back to the standard allocator.",
+			poolLabels, nil,
+		),
+	}
+}
+
+func (c memMetrics) describe(ch chan<- *prometheus.Desc) {
+	for _, field := range c.mallinfo {
+		ch <- field.desc
+	}
+	ch <- c.mempoolHot
+	ch <- c.mempoolCold
+	ch <- c.mempoolPaddedSize
+	ch <- c.mempoolAllocations
+	ch <- c.mempoolMaxAlloc
+	ch <- c.mempoolMisses
+}
+
+// collectMem reads the memory statistics of the brick processes of volume
+func (m *Metrics) collectMem(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	memStatus, err := m.client.GetVolumeStatusMem(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get memory status of volume %v: %v", volume, err)
+		return
+	}
+
+	for _, vol := range memStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			// offline bricks have no process to report on
+			if node.Status != 1 {
+				continue
+			}
+
+			for _, field := range m.mem.mallinfo {
+				ch <- prometheus.MustNewConstMetric(
+					field.desc, prometheus.GaugeValue, float64(field.value(node.MemStatus.Mallinfo)), node.Hostname, node.Path, vol.VolName,
+				)
+			}
+
+			for _, pool := range node.MemStatus.Mempool.Pool {
+				labels := []string{node.Hostname, node.Path, vol.VolName, pool.Name}
+				ch <- prometheus.MustNewConstMetric(
+					m.mem.mempoolHot, prometheus.GaugeValue, float64(pool.HotCount), labels...,
+				)
+				ch <- prometheus.MustNewConstMetric(
+					m.mem.mempoolCold, prometheus.GaugeValue, float64(pool.ColdCount), labels...,
+				)
+				ch <- prometheus.MustNewConstMetric(
+					m.mem.mempoolPaddedSize, prometheus.GaugeValue, float64(pool.PaddedSizeOf), labels...,
+				)
+				ch <- prometheus.MustNewConstMetric(
+					m.mem.mempoolAllocations, prometheus.CounterValue, float64(pool.AllocCount), labels...,
+				)
+				ch <- prometheus.MustNewConstMetric(
+					m.mem.mempoolMaxAlloc, prometheus.GaugeValue, float64(pool.MaxAlloc), labels...,
+				)
+				ch <- prometheus.MustNewConstMetric(
+					m.mem.mempoolMisses, prometheus.CounterValue, float64(pool.PoolMisses), labels...,
+				)
+			}
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package metrics
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/nilpntr/gluster-exporter/internal/gluster"
 	"github.com/nilpntr/gluster-exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
@@ -9,17 +11,21 @@ import (
 	"go.uber.org/zap"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
-const (
-	namespace  = "gluster"
-	allVolumes = "_all"
-)
+const namespace = "gluster"
 
 type Metrics struct {
-	hostname string
-	volumes  []string
+	client      *gluster.Client
+	hostname    string
+	volumes     []string
+	interval    time.Duration
+	concurrency int
+	snapshot    atomic.Pointer[snapshot]
 
 	up                     *prometheus.Desc
 	volumesCount           *prometheus.Desc
@@ -29,6 +35,8 @@ type Metrics struct {
 	nodeInodesTotal        *prometheus.Desc
 	nodeInodesFree         *prometheus.Desc
 	brickCount             *prometheus.Desc
+	brickUp                *prometheus.Desc
+	brickInfo              *prometheus.Desc
 	brickDuration          *prometheus.Desc
 	brickDataRead          *prometheus.Desc
 	brickDataWritten       *prometheus.Desc
@@ -46,15 +54,41 @@ type Metrics struct {
 	quotaAvailable         *prometheus.Desc
 	quotaSoftLimitExceeded *prometheus.Desc
 	quotaHardLimitExceeded *prometheus.Desc
+	commandTimeouts        *prometheus.Desc
+	commandRetries         *prometheus.Desc
+	snapshotAge            *prometheus.Desc
+	lastSuccess            *prometheus.Desc
+
+	clients         clientMetrics
+	mem             memMetrics
+	fd              fdMetrics
+	inode           inodeMetrics
+	callpool        callpoolMetrics
+	top             topMetrics
+	heal            healMetrics
+	healStatistics  healStatisticsMetrics
+	geoRep          geoRepMetrics
+	rebalance       rebalanceMetrics
+	volumeSnapshots volumeSnapshotMetrics
+	bitrot          bitrotMetrics
+	volumeOptions   volumeOptionMetrics
+	volumeInfo      volumeInfoMetrics
 }
 
-func New() (*Metrics, error) {
+func New(client *gluster.Client) (*Metrics, error) {
 	hostname, err := os.Hostname()
 	if err != nil {
 		return nil, err
 	}
 
-	if !utils.FileExists(viper.GetString("gluster_binary")) {
+	runner := client.Runner()
+	if recorder, ok := runner.(*gluster.RecordingRunner); ok {
+		runner = recorder.Runner
+	}
+	if runner, ok := runner.(*gluster.ExecRunner); ok && !utils.ExecutableExists(runner.Executable()) {
+		if len(runner.Prefix) > 0 {
+			return nil, fmt.Errorf("gluster command wrapper %v not found", runner.Executable())
+		}
 		return nil, errors.New("gluster binary not found")
 	}
 
@@ -63,6 +97,16 @@ func New() (*Metrics, error) {
 		return nil, errors.New("no gluster volumes provided")
 	}
 
+	top, err := newTopMetrics(viper.GetString("collector_top_ops"), viper.GetBool("collector_top_perf"), viper.GetInt("collector_top_list_cnt"))
+	if err != nil {
+		return nil, err
+	}
+
+	volumeOptions, err := newVolumeOptionMetrics(viper.GetString("collector_volume_options"), viper.GetString("collector_volume_options_policy"))
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		up = prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "up"),
@@ -112,6 +156,18 @@ func New() (*Metrics, error) {
 			[]string{"volume"}, nil,
 		)
 
+		brickUp = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_up"),
+			"Is the brick process online according to 'gluster volume status'.",
+			[]string{"volume", "hostname", "path"}, nil,
+		)
+
+		brickInfo = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_info"),
+			"Port, PID and filesystem of the brick process, always 1.",
+			[]string{"volume", "hostname", "path", "peer_id", "port", "rdma_port", "pid", "device", "fs_name", "mount_options"}, nil,
+		)
+
 		brickDuration = prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "brick_duration_seconds_total"),
 			"Time running volume brick in seconds.",
@@ -162,7 +218,7 @@ func New() (*Metrics, error) {
 
 		healInfoFilesCount = prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "", "heal_info_files_count"),
-			"File count of files out of sync, when calling 'gluster v heal VOLNAME info",
+			"File count of files out of sync of the connected bricks, when calling 'gluster v heal VOLNAME info'.",
 			[]string{"volume"}, nil)
 
 		volumeWriteable = prometheus.NewDesc(
@@ -204,11 +260,34 @@ func New() (*Metrics, error) {
 			prometheus.BuildFQName(namespace, "", "volume_quota_hardlimit_exceeded"),
 			"Is the quota hard-limit exceeded",
 			[]string{"path", "volume"}, nil)
+
+		commandTimeouts = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "command_timeouts_total"),
+			"Number of gluster commands killed because they ran into their timeout",
+			[]string{"command"}, nil)
+
+		commandRetries = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "command_retries_total"),
+			"Number of gluster commands retried because glusterd was locked by another transaction",
+			[]string{"command", "reason"}, nil)
+
+		snapshotAge = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "snapshot_age_seconds"),
+			"Age of the background collected snapshot served to this scrape",
+			nil, nil)
+
+		lastSuccess = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "last_success_timestamp_seconds"),
+			"Unix timestamp of the last background collection with gluster up",
+			nil, nil)
 	)
 
 	return &Metrics{
+		client:                 client,
 		hostname:               hostname,
 		volumes:                volumes,
+		interval:               viper.GetDuration("collector_interval"),
+		concurrency:            viper.GetInt("collector_concurrency"),
 		up:                     up,
 		volumesCount:           volumesCount,
 		volumeStatus:           volumeStatus,
@@ -217,6 +296,8 @@ func New() (*Metrics, error) {
 		nodeInodesTotal:        nodeInodesTotal,
 		nodeInodesFree:         nodeInodesFree,
 		brickCount:             brickCount,
+		brickUp:                brickUp,
+		brickInfo:              brickInfo,
 		brickDuration:          brickDuration,
 		brickDataRead:          brickDataRead,
 		brickDataWritten:       brickDataWritten,
@@ -234,6 +315,24 @@ func New() (*Metrics, error) {
 		quotaAvailable:         quotaAvailable,
 		quotaSoftLimitExceeded: quotaSoftLimitExceeded,
 		quotaHardLimitExceeded: quotaHardLimitExceeded,
+		commandTimeouts:        commandTimeouts,
+		commandRetries:         commandRetries,
+		snapshotAge:            snapshotAge,
+		lastSuccess:            lastSuccess,
+		clients:                newClientMetrics(viper.GetBool("collector_clients_hostnames")),
+		mem:                    newMemMetrics(),
+		fd:                     newFdMetrics(),
+		inode:                  newInodeMetrics(),
+		callpool:               newCallpoolMetrics(),
+		top:                    top,
+		heal:                   newHealMetrics(),
+		healStatistics:         newHealStatisticsMetrics(),
+		geoRep:                 newGeoRepMetrics(),
+		rebalance:              newRebalanceMetrics(),
+		volumeSnapshots:        newVolumeSnapshotMetrics(),
+		bitrot:                 newBitrotMetrics(),
+		volumeOptions:          volumeOptions,
+		volumeInfo:             newVolumeInfoMetrics(),
 	}, nil
 }
 
@@ -242,6 +341,8 @@ func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 	ch <- m.volumeStatus
 	ch <- m.volumesCount
 	ch <- m.brickCount
+	ch <- m.brickUp
+	ch <- m.brickInfo
 	ch <- m.brickDuration
 	ch <- m.brickDataRead
 	ch <- m.brickDataWritten
@@ -261,21 +362,71 @@ func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 	ch <- m.quotaAvailable
 	ch <- m.quotaSoftLimitExceeded
 	ch <- m.quotaHardLimitExceeded
+	ch <- m.commandTimeouts
+	ch <- m.commandRetries
+	ch <- m.snapshotAge
+	ch <- m.lastSuccess
+	m.clients.describe(ch)
+	m.mem.describe(ch)
+	m.fd.describe(ch)
+	m.inode.describe(ch)
+	m.callpool.describe(ch)
+	m.top.describe(ch)
+	m.heal.describe(ch)
+	m.healStatistics.describe(ch)
+	m.geoRep.describe(ch)
+	m.rebalance.describe(ch)
+	m.volumeSnapshots.describe(ch)
+	m.bitrot.describe(ch)
+	m.volumeOptions.describe(ch)
+	m.volumeInfo.describe(ch)
 }
 
 func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	if m.interval > 0 {
+		m.collectSnapshot(ch)
+		return
+	}
+	m.collect(context.Background(), ch)
+}
+
+// collectedVolumes returns the volumes the per volume commands run for. When
+// volume info failed they are still run for the configured volumes, or all
+// volumes of "gluster volume list".
+func (m *Metrics) collectedVolumes(ctx context.Context, volumeInfo gluster.VolumeInfoXML, up bool) []string {
+	var volumes []string
+	if up {
+		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+			if m.volumes[0] == gluster.AllVolumes || slices.Contains(m.volumes, volume.Name) {
+				volumes = append(volumes, volume.Name)
+			}
+		}
+		return volumes
+	}
+
+	if m.volumes[0] != gluster.AllVolumes {
+		return m.volumes
+	}
+	volumeList, err := m.client.GetVolumeList(ctx)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get volume list: %v", err)
+	}
+	return volumeList.Volume
+}
+
+// collect runs the gluster commands and sends the resulting metrics to ch,
+// it returns whether gluster was up
+func (m *Metrics) collect(ctx context.Context, ch chan<- prometheus.Metric) bool {
 	// Collect metrics from volume info
-	volumeInfo, err := gluster.GetVolumeInfo()
+	volumeInfo, err := m.client.GetVolumeInfo(ctx)
 	// Couldn't parse xml, so something is really wrong and up=0
 	if err != nil {
 		zap.L().Sugar().Errorf("couldn't parse xml volume info: %v", err)
-		ch <- prometheus.MustNewConstMetric(
-			m.up, prometheus.GaugeValue, 0.0,
-		)
 	}
 
 	// use OpErrno as indicator for up
-	if volumeInfo.OpErrno != 0 {
+	up := err == nil && volumeInfo.OpErrno == 0
+	if !up {
 		ch <- prometheus.MustNewConstMetric(
 			m.up, prometheus.GaugeValue, 0.0,
 		)
@@ -290,7 +441,7 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 	)
 
 	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
-		if m.volumes[0] == allVolumes || slices.Contains(m.volumes, volume.Name) {
+		if m.volumes[0] == gluster.AllVolumes || slices.Contains(m.volumes, volume.Name) {
 
 			ch <- prometheus.MustNewConstMetric(
 				m.brickCount, prometheus.GaugeValue, float64(volume.BrickCount), volume.Name,
@@ -299,11 +450,14 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 			ch <- prometheus.MustNewConstMetric(
 				m.volumeStatus, prometheus.GaugeValue, float64(volume.Status), volume.Name,
 			)
+
+			m.collectVolumeInfo(volume, ch)
+			m.collectVolumeOptions(ctx, volume, ch)
 		}
 	}
 
 	// reads gluster peer status
-	peerStatus, peerStatusErr := gluster.GetPeerStatus()
+	peerStatus, peerStatusErr := m.client.GetPeerStatus(ctx)
 	if peerStatusErr != nil {
 		zap.L().Sugar().Errorf("couldn't parse xml of peer status: %v", peerStatusErr)
 	}
@@ -315,57 +469,44 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 		m.peersConnected, prometheus.GaugeValue, float64(count),
 	)
 
-	// reads profile info
-	if viper.GetBool("profile") {
-		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
-			if m.volumes[0] == allVolumes || slices.Contains(m.volumes, volume.Name) {
-				volumeProfile, execVolProfileErr := gluster.GetVolumeProfileGvInfoCumulative(volume.Name)
-				if execVolProfileErr != nil {
-					zap.L().Sugar().Errorf("Error while executing or marshalling gluster profile output: %v", execVolProfileErr)
-				}
-				for _, brick := range volumeProfile.Brick {
-					if strings.HasPrefix(brick.BrickName, m.hostname) {
-						ch <- prometheus.MustNewConstMetric(
-							m.brickDuration, prometheus.CounterValue, float64(brick.CumulativeStats.Duration), volume.Name, brick.BrickName,
-						)
-
-						ch <- prometheus.MustNewConstMetric(
-							m.brickDataRead, prometheus.CounterValue, float64(brick.CumulativeStats.TotalRead), volume.Name, brick.BrickName,
-						)
-
-						ch <- prometheus.MustNewConstMetric(
-							m.brickDataWritten, prometheus.CounterValue, float64(brick.CumulativeStats.TotalWrite), volume.Name, brick.BrickName,
-						)
-						for _, fop := range brick.CumulativeStats.FopStats.Fop {
-							ch <- prometheus.MustNewConstMetric(
-								m.brickFopHits, prometheus.CounterValue, float64(fop.Hits), volume.Name, brick.BrickName, fop.Name,
-							)
-
-							ch <- prometheus.MustNewConstMetric(
-								m.brickFopLatencyAvg, prometheus.GaugeValue, fop.AvgLatency, volume.Name, brick.BrickName, fop.Name,
-							)
-
-							ch <- prometheus.MustNewConstMetric(
-								m.brickFopLatencyMin, prometheus.GaugeValue, fop.MinLatency, volume.Name, brick.BrickName, fop.Name,
-							)
-
-							ch <- prometheus.MustNewConstMetric(
-								m.brickFopLatencyMax, prometheus.GaugeValue, fop.MaxLatency, volume.Name, brick.BrickName, fop.Name,
-							)
-						}
-					}
-				}
-			}
-		}
-	}
+	// runs the per volume commands
+	volumes := m.collectedVolumes(ctx, volumeInfo, up)
+	m.forEachVolume(volumes, ch, func(volume string, ch chan<- prometheus.Metric) {
+		m.collectVolume(ctx, volume, ch)
+	})
+	m.callpool.stacks.prune(volumes)
 
 	// executes gluster status all detail
-	volumeStatusAll, err := gluster.GetVolumeStatusAllDetail()
+	volumeStatusAll, err := m.client.GetVolumeStatusAllDetail(ctx)
 	if err != nil {
 		zap.L().Sugar().Errorf("couldn't parse xml of peer status: %v", err)
 	}
 	for _, vol := range volumeStatusAll.VolStatus.Volumes.Volume {
 		for _, node := range vol.Node {
+			online := node.Status == 1
+			brickUp := 0.0
+			if online {
+				brickUp = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.brickUp, prometheus.GaugeValue, brickUp, vol.VolName, node.Hostname, node.Path,
+			)
+
+			// older releases only report the tcp port as port
+			port := node.Ports.TCP
+			if port == "" {
+				port = node.Port
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.brickInfo, prometheus.GaugeValue, 1.0, vol.VolName, node.Hostname, node.Path, node.PeerID,
+				port, node.Ports.RDMA, strconv.Itoa(node.Pid), node.Device, node.FsName, node.MntOptions,
+			)
+
+			// offline bricks report zero sizes, which would look like a full disk
+			if !online {
+				continue
+			}
+
 			ch <- prometheus.MustNewConstMetric(
 				m.nodeSizeTotalBytes, prometheus.CounterValue, float64(node.SizeTotal), node.Hostname, node.Path, vol.VolName,
 			)
@@ -382,25 +523,200 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 			)
 		}
 	}
-	vols := m.volumes
-	if vols[0] == allVolumes {
-		zap.L().Sugar().Warn("no Volumes were given.")
-		volumeList, volumeListErr := gluster.GetVolumeList()
-		if volumeListErr != nil {
-			zap.L().Sugar().Error(volumeListErr)
+
+	if viper.GetBool("collector_georep") {
+		m.collectGeoRep(ctx, ch)
+	}
+
+	if viper.GetBool("collector_snapshots") {
+		m.collectVolumeSnapshots(ctx, ch)
+	}
+
+	// mount checks only make sense on the node running glusterd
+	if m.client.Local() {
+		m.collectMounts(ch)
+	}
+
+	for command, count := range m.client.TimeoutCounts() {
+		ch <- prometheus.MustNewConstMetric(
+			m.commandTimeouts, prometheus.CounterValue, float64(count), command,
+		)
+	}
+
+	for key, count := range m.client.RetryCounts() {
+		ch <- prometheus.MustNewConstMetric(
+			m.commandRetries, prometheus.CounterValue, float64(count), key.Command, key.Reason,
+		)
+	}
+	return up
+}
+
+// collectVolume runs the commands of the enabled per volume collectors
+func (m *Metrics) collectVolume(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	if viper.GetBool("profile") {
+		m.collectProfile(ctx, volume, ch)
+	}
+
+	m.collectHealInfo(ctx, volume, ch)
+
+	if viper.GetBool("collector_heal_statistics") {
+		m.collectHealStatistics(ctx, volume, ch)
+	}
+
+	if viper.GetBool("quota") {
+		m.collectQuota(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_clients") {
+		m.collectClients(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_mem") {
+		m.collectMem(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_fd") {
+		m.collectFd(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_inode") {
+		m.collectInode(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_callpool") {
+		m.collectCallpool(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_top") {
+		m.collectTop(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_rebalance") {
+		m.collectRebalance(ctx, volume, ch)
+	}
+
+	if viper.GetBool("collector_bitrot") {
+		m.collectBitrot(ctx, volume, ch)
+	}
+}
+
+// ownBrick reports whether the brick with name, e.g. node1:/bricks/gv, runs on
+// this host. Without a local glusterd there is no own brick to pick, all of
+// them are reported then.
+func (m *Metrics) ownBrick(name string) bool {
+	return !m.client.Local() || strings.HasPrefix(name, m.hostname)
+}
+
+// collectProfile reads the cumulative profile info of volume
+func (m *Metrics) collectProfile(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	volumeProfile, execVolProfileErr := m.client.GetVolumeProfileGvInfoCumulative(ctx, volume)
+	if execVolProfileErr != nil {
+		zap.L().Sugar().Errorf("Error while executing or marshalling gluster profile output: %v", execVolProfileErr)
+	}
+	for _, brick := range volumeProfile.Brick {
+		if m.ownBrick(brick.BrickName) {
+			ch <- prometheus.MustNewConstMetric(
+				m.brickDuration, prometheus.CounterValue, float64(brick.CumulativeStats.Duration), volume, brick.BrickName,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				m.brickDataRead, prometheus.CounterValue, float64(brick.CumulativeStats.TotalRead), volume, brick.BrickName,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				m.brickDataWritten, prometheus.CounterValue, float64(brick.CumulativeStats.TotalWrite), volume, brick.BrickName,
+			)
+			for _, fop := range brick.CumulativeStats.FopStats.Fop {
+				ch <- prometheus.MustNewConstMetric(
+					m.brickFopHits, prometheus.CounterValue, float64(fop.Hits), volume, brick.BrickName, fop.Name,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					m.brickFopLatencyAvg, prometheus.GaugeValue, fop.AvgLatency, volume, brick.BrickName, fop.Name,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					m.brickFopLatencyMin, prometheus.GaugeValue, fop.MinLatency, volume, brick.BrickName, fop.Name,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					m.brickFopLatencyMax, prometheus.GaugeValue, fop.MaxLatency, volume, brick.BrickName, fop.Name,
+				)
+			}
 		}
-		vols = volumeList.Volume
 	}
+}
+
+// collectQuota reads the quota limits of volume
+func (m *Metrics) collectQuota(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	volumeQuotaXML, err := m.client.GetVolumeQuotaList(ctx, volume)
+	var cliErr *gluster.CLIError
+	if errors.As(err, &cliErr) && cliErr.HasMessage("quota is disabled") {
+		zap.L().Sugar().Errorf("Cannot create quota metrics for %v, quota is not enabled in your gluster server", volume)
+	} else if err != nil {
+		zap.L().Sugar().Errorf("couldn't get quota list of %v: %v", volume, err)
+	} else {
+		for _, limit := range volumeQuotaXML.VolQuota.QuotaLimits {
+			ch <- prometheus.MustNewConstMetric(
+				m.quotaHardLimit,
+				prometheus.CounterValue,
+				float64(limit.HardLimit),
+				limit.Path,
+				volume,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				m.quotaSoftLimit,
+				prometheus.CounterValue,
+				float64(limit.SoftLimitValue),
+				limit.Path,
+				volume,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				m.quotaUsed,
+				prometheus.CounterValue,
+				float64(limit.UsedSpace),
+				limit.Path,
+				volume,
+			)
 
-	for _, vol := range vols {
-		filesCount, volumeHealErr := gluster.GetVolumeHealInfo(vol)
-		if volumeHealErr == nil {
 			ch <- prometheus.MustNewConstMetric(
-				m.healInfoFilesCount, prometheus.CounterValue, float64(filesCount), vol,
+				m.quotaAvailable,
+				prometheus.CounterValue,
+				float64(limit.AvailSpace),
+				limit.Path,
+				volume,
+			)
+
+			slExceeded := 0.0
+			if limit.SlExceeded != "No" {
+				slExceeded = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.quotaSoftLimitExceeded,
+				prometheus.CounterValue,
+				slExceeded,
+				limit.Path,
+				volume,
+			)
+
+			hlExceeded := 0.0
+			if limit.HlExceeded != "No" {
+				hlExceeded = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.quotaHardLimitExceeded,
+				prometheus.CounterValue,
+				hlExceeded,
+				limit.Path,
+				volume,
 			)
 		}
 	}
+}
 
+// collectMounts checks that the glusterfs fuse mounts of this node are writeable
+func (m *Metrics) collectMounts(ch chan<- prometheus.Metric) {
 	mountBuffer, execMountCheckErr := gluster.GetMountCheck()
 	if execMountCheckErr != nil {
 		zap.L().Sugar().Error(execMountCheckErr)
@@ -437,71 +753,4 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 	}
-	if viper.GetBool("quota") {
-		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
-			if m.volumes[0] == allVolumes || slices.Contains(m.volumes, volume.Name) {
-				volumeQuotaXML, err := gluster.GetVolumeQuotaList(volume.Name)
-				if err != nil {
-					zap.L().Sugar().Error("Cannot create quota metrics if quotas are not enabled in your gluster server")
-				} else {
-					for _, limit := range volumeQuotaXML.VolQuota.QuotaLimits {
-						ch <- prometheus.MustNewConstMetric(
-							m.quotaHardLimit,
-							prometheus.CounterValue,
-							float64(limit.HardLimit),
-							limit.Path,
-							volume.Name,
-						)
-
-						ch <- prometheus.MustNewConstMetric(
-							m.quotaSoftLimit,
-							prometheus.CounterValue,
-							float64(limit.SoftLimitValue),
-							limit.Path,
-							volume.Name,
-						)
-						ch <- prometheus.MustNewConstMetric(
-							m.quotaUsed,
-							prometheus.CounterValue,
-							float64(limit.UsedSpace),
-							limit.Path,
-							volume.Name,
-						)
-
-						ch <- prometheus.MustNewConstMetric(
-							m.quotaAvailable,
-							prometheus.CounterValue,
-							float64(limit.AvailSpace),
-							limit.Path,
-							volume.Name,
-						)
-
-						slExceeded := 0.0
-						if limit.SlExceeded != "No" {
-							slExceeded = 1.0
-						}
-						ch <- prometheus.MustNewConstMetric(
-							m.quotaSoftLimitExceeded,
-							prometheus.CounterValue,
-							slExceeded,
-							limit.Path,
-							volume.Name,
-						)
-
-						hlExceeded := 0.0
-						if limit.HlExceeded != "No" {
-							hlExceeded = 1.0
-						}
-						ch <- prometheus.MustNewConstMetric(
-							m.quotaHardLimitExceeded,
-							prometheus.CounterValue,
-							hlExceeded,
-							limit.Path,
-							volume.Name,
-						)
-					}
-				}
-			}
-		}
-	}
 }
@@ -0,0 +1,798 @@
+package metrics
+
+import (
+	"context"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+const fixturesDir = "../../test"
+
+// overrideRunner answers the commands in files from other fixtures than the
+// default ones, e.g. to simulate an offline brick
+type overrideRunner struct {
+	gluster.Runner
+	files map[string]string
+}
+
+func (r overrideRunner) Run(ctx context.Context, args ...string) (gluster.Result, error) {
+	if file, ok := r.files[gluster.FixtureName(args)]; ok {
+		dat, err := os.ReadFile(filepath.Join(fixturesDir, file))
+		return gluster.Result{Stdout: dat}, err
+	}
+	return r.Runner.Run(ctx, args...)
+}
+
+func newTestMetrics(t *testing.T) (*Metrics, *gluster.RecordingRunner) {
+	return newTestMetricsWithFixtures(t, nil)
+}
+
+// newTestMetricsWithFixtures replaces the fixtures of files, keyed by the
+// default fixture name
+func newTestMetricsWithFixtures(t *testing.T, files map[string]string) (*Metrics, *gluster.RecordingRunner) {
+	t.Helper()
+	return newTestMetricsWithRunner(t, overrideRunner{Runner: gluster.NewFixtureRunner(fixturesDir), files: files})
+}
+
+// newTestMetricsWithRunner answers the gluster commands with runner
+func newTestMetricsWithRunner(t *testing.T, glusterRunner gluster.Runner) (*Metrics, *gluster.RecordingRunner) {
+	t.Helper()
+	viper.Set("gluster_volumes", gluster.AllVolumes)
+	viper.Set("profile", true)
+	viper.Set("quota", true)
+	t.Cleanup(viper.Reset)
+
+	runner := gluster.NewRecordingRunner(glusterRunner)
+	m, err := New(gluster.NewClient(runner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, runner
+}
+
+func gatherFamilies(t *testing.T, m *Metrics) map[string]*dto.MetricFamily {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := make(map[string]*dto.MetricFamily, len(families))
+	for _, family := range families {
+		res[family.GetName()] = family
+	}
+	return res
+}
+
+func findMetric(family *dto.MetricFamily, labels map[string]string) *dto.Metric {
+	for _, metric := range family.GetMetric() {
+		matched := 0
+		for _, label := range metric.GetLabel() {
+			if value, ok := labels[label.GetName()]; ok && value == label.GetValue() {
+				matched++
+			}
+		}
+		if matched == len(labels) {
+			return metric
+		}
+	}
+	return nil
+}
+
+func metricValue(metric *dto.Metric) float64 {
+	if metric.GetGauge() != nil {
+		return metric.GetGauge().GetValue()
+	}
+	return metric.GetCounter().GetValue()
+}
+
+// metricCase is a series expected in the gathered metric families, labels
+// may only name some of its labels
+type metricCase struct {
+	name     string
+	labels   map[string]string
+	expected float64
+}
+
+// assertMetrics fails for every case whose series is missing or has another value
+func assertMetrics(t *testing.T, families map[string]*dto.MetricFamily, cases []metricCase) {
+	t.Helper()
+	for _, c := range cases {
+		family, ok := families[c.name]
+		if !ok {
+			t.Errorf("metric %v was not collected", c.name)
+			continue
+		}
+		metric := findMetric(family, c.labels)
+		if metric == nil {
+			t.Errorf("no %v metric with labels %v", c.name, c.labels)
+			continue
+		}
+		if value := metricValue(metric); value != c.expected {
+			t.Errorf("%v%v is %v and %v was expected", c.name, c.labels, value, c.expected)
+		}
+	}
+}
+
+func TestCollectFixtures(t *testing.T) {
+	m, _ := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_up", expected: 1},
+		{name: "gluster_volumes_available", expected: 2},
+		{name: "gluster_peers_connected", expected: 3},
+		{name: "gluster_brick_available", labels: map[string]string{"volume": "gv_test"}, expected: 4},
+		{name: "gluster_volume_status", labels: map[string]string{"volume": "gv_cluster"}, expected: 1},
+		{name: "gluster_node_size_free_bytes", labels: map[string]string{"volume": "gv_test", "hostname": "node1.example.local"}, expected: 19517558784},
+		{name: "gluster_brick_fop_hits_total", labels: map[string]string{"volume": "gv_test", "brick": "node1.example.local:/mnt/gluster/gv_test", "fop_name": "WRITE"}, expected: 58},
+		{name: "gluster_heal_info_files_count", labels: map[string]string{"volume": "gv_test"}, expected: 6},
+		{name: "gluster_heal_split_brain_entries", labels: map[string]string{"volume": "gv_test", "brick": "node1.example.com:/mnt/gluster/gv_test"}, expected: 2},
+		{name: "gluster_volume_quota_available", labels: map[string]string{"volume": "gv_test", "path": "/foo"}, expected: 10309258240},
+	})
+}
+
+func TestCollectSkipsMountsWithoutLocalGlusterd(t *testing.T) {
+	m, _ := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+
+	for _, name := range []string{"gluster_mount_successful", "gluster_volume_writeable"} {
+		if _, ok := families[name]; ok {
+			t.Errorf("metric %v was collected from fixtures", name)
+		}
+	}
+}
+
+func TestCollectRunsCommands(t *testing.T) {
+	m, runner := newTestMetrics(t)
+	gatherFamilies(t, m)
+
+	calls := runner.Calls()
+	if len(calls) == 0 {
+		t.Fatal("no gluster commands were executed")
+	}
+	for _, call := range calls {
+		if call.Args[len(call.Args)-1] != "--xml" {
+			t.Errorf("expected --xml as last argument of %v", call.Args)
+		}
+	}
+	if calls[0].Args[0] != "volume" || calls[0].Args[1] != "info" {
+		t.Errorf("expected volume info as first command and got %v", calls[0].Args)
+	}
+}
+
+func TestCollectSnapshot(t *testing.T) {
+	m, runner := newTestMetrics(t)
+	m.interval = time.Minute
+
+	if families := gatherFamilies(t, m); len(families) != 0 {
+		t.Errorf("expected no metrics before the first snapshot and got %v families", len(families))
+	}
+
+	m.refreshSnapshot(context.Background())
+	executed := len(runner.Calls())
+
+	families := gatherFamilies(t, m)
+	gatherFamilies(t, m)
+	if len(runner.Calls()) != executed {
+		t.Errorf("scrapes executed %v gluster commands", len(runner.Calls())-executed)
+	}
+
+	for _, name := range []string{"gluster_up", "gluster_exporter_snapshot_age_seconds", "gluster_exporter_last_success_timestamp_seconds"} {
+		if _, ok := families[name]; !ok {
+			t.Errorf("metric %v was not collected", name)
+		}
+	}
+}
+
+func collectOrder(m *Metrics) []string {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		m.collect(context.Background(), ch)
+		close(ch)
+	}()
+
+	var res []string
+	for metric := range ch {
+		written := &dto.Metric{}
+		_ = metric.Write(written)
+		res = append(res, metric.Desc().String()+written.String())
+	}
+	return res
+}
+
+func TestCollectConcurrency(t *testing.T) {
+	m, _ := newTestMetrics(t)
+	sequential := collectOrder(m)
+
+	m.concurrency = 4
+	for i := 0; i < 5; i++ {
+		parallel := collectOrder(m)
+		if !slices.Equal(sequential, parallel) {
+			t.Fatalf("parallel collection returned %v metrics in a different order than %v sequential ones", len(parallel), len(sequential))
+		}
+	}
+}
+
+func TestCollectOfflineBrick(t *testing.T) {
+	m, _ := newTestMetricsWithFixtures(t, map[string]string{
+		"gluster_volume_status_all_detail.xml": "gluster_volume_status_all_detail_offline.xml",
+	})
+	families := gatherFamilies(t, m)
+
+	online := map[string]string{"volume": "gv_test", "hostname": "node1.example.local"}
+	offline := map[string]string{"volume": "gv_test", "hostname": "node2.example.local"}
+
+	if value := metricValue(findMetric(families["gluster_brick_up"], online)); value != 1 {
+		t.Errorf("expected online brick to be up and got %v", value)
+	}
+	if metric := findMetric(families["gluster_brick_up"], offline); metric == nil || metricValue(metric) != 0 {
+		t.Errorf("expected offline brick to be reported as down and got %v", metric)
+	}
+
+	info := findMetric(families["gluster_brick_info"], map[string]string{"hostname": "node1.example.local", "port": "49153", "pid": "1342", "fs_name": "ext4"})
+	if info == nil {
+		t.Error("no brick info with port, pid and fs_name of node1")
+	}
+
+	for _, name := range []string{"gluster_node_size_free_bytes", "gluster_node_size_bytes_total", "gluster_node_inodes_free", "gluster_node_inodes_total"} {
+		if findMetric(families[name], online) == nil {
+			t.Errorf("%v is missing for the online brick", name)
+		}
+		if findMetric(families[name], offline) != nil {
+			t.Errorf("%v was reported for the offline brick", name)
+		}
+	}
+}
+
+func TestCollectClients(t *testing.T) {
+	brick := map[string]string{"volume": "gv_test", "hostname": "node1.example.local", "path": "/mnt/gluster/gv_test"}
+
+	viper.Set("collector_clients", true)
+	m, _ := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+	if value := metricValue(findMetric(families["gluster_brick_clients"], brick)); value != 3 {
+		t.Errorf("expected 3 clients and got %v", value)
+	}
+	if value := metricValue(findMetric(families["gluster_brick_client_read_bytes"], brick)); value != 1432+18204+2216460 {
+		t.Errorf("expected the bytes read of all clients and got %v", value)
+	}
+	// the sums drop when a client disconnects, so they aren't counters
+	if families["gluster_brick_client_read_bytes"].GetType() != dto.MetricType_GAUGE {
+		t.Errorf("expected a gauge, got %v", families["gluster_brick_client_read_bytes"].GetType())
+	}
+
+	viper.Set("collector_clients", true)
+	viper.Set("collector_clients_hostnames", true)
+	m, _ = newTestMetrics(t)
+	families = gatherFamilies(t, m)
+	perHost := map[string]string{"path": "/mnt/gluster/gv_test", "hostname": "node1.example.local", "client": "10.0.0.11"}
+	if value := metricValue(findMetric(families["gluster_brick_client_written_bytes"], perHost)); value != 1068+13440 {
+		t.Errorf("expected the bytes written of both connections of 10.0.0.11 and got %v", value)
+	}
+	if len(families["gluster_brick_client_written_bytes"].GetMetric()) != 3 {
+		t.Errorf("expected one series per brick and client host, got %v", families["gluster_brick_client_written_bytes"].GetMetric())
+	}
+}
+
+func TestCollectMem(t *testing.T) {
+	brick := map[string]string{"volume": "gv_test", "hostname": "node2.example.local", "path": "/mnt/gluster/gv_test"}
+
+	viper.Set("collector_mem", true)
+	m, _ := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+	if value := metricValue(findMetric(families["gluster_node_mallinfo_uordblks_bytes"], brick)); value != 4096112 {
+		t.Errorf("expected uordblks of node2 and got %v", value)
+	}
+
+	brick["pool"] = "gv_test-server:inode_t"
+	if value := metricValue(findMetric(families["gluster_node_mempool_hot_count"], brick)); value != 13 {
+		t.Errorf("expected hot count of the inode pool and got %v", value)
+	}
+	if value := metricValue(findMetric(families["gluster_node_mempool_allocations_total"], brick)); value != 311 {
+		t.Errorf("expected allocations of the inode pool and got %v", value)
+	}
+	if len(families["gluster_node_mempool_misses_total"].GetMetric()) != 6 {
+		t.Errorf("expected a series per brick and pool, got %v", families["gluster_node_mempool_misses_total"].GetMetric())
+	}
+}
+
+func TestCollectFdInode(t *testing.T) {
+	brick := map[string]string{"volume": "gv_test", "hostname": "node1.example.local", "path": "/mnt/gluster/gv_test"}
+
+	viper.Set("collector_fd", true)
+	m, _ := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_brick_open_fds", labels: brick, expected: 4},
+		{name: "gluster_brick_fd_connections", labels: brick, expected: 3},
+		{name: "gluster_brick_connection_open_fds_max", labels: brick, expected: 3},
+		{name: "gluster_brick_connection_max_fds", labels: brick, expected: 128},
+	})
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "connection" {
+					t.Errorf("expected no positional connection label on %v", family.GetName())
+				}
+			}
+		}
+	}
+	if _, ok := families["gluster_brick_inode_table_active"]; ok {
+		t.Error("inode metrics have to be enabled separately")
+	}
+
+	viper.Set("collector_fd", false)
+	viper.Set("collector_inode", true)
+	m, _ = newTestMetrics(t)
+	families = gatherFamilies(t, m)
+	if value := metricValue(findMetric(families["gluster_brick_inode_table_lru"], brick)); value != 3 {
+		t.Errorf("expected 3 lru inodes on node1 and got %v", value)
+	}
+	if _, ok := families["gluster_brick_open_fds"]; ok {
+		t.Error("fd metrics have to be enabled separately")
+	}
+}
+
+func TestCollectCallpool(t *testing.T) {
+	node1 := map[string]string{"volume": "gv_test", "hostname": "node1.example.local", "path": "/mnt/gluster/gv_test"}
+	node2 := map[string]string{"volume": "gv_test", "hostname": "node2.example.local", "path": "/mnt/gluster/gv_test"}
+
+	viper.Set("collector_callpool", true)
+	m, _ := newTestMetrics(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.callpool.stacks.now = func() time.Time { return now }
+
+	families := gatherFamilies(t, m)
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_brick_callpool_pending_stacks", labels: node1, expected: 2},
+		{name: "gluster_brick_callpool_pending_frames", labels: node1, expected: 5},
+		// new stacks have no age yet
+		{name: "gluster_brick_callpool_oldest_stack_age_seconds", labels: node1, expected: 0},
+	})
+
+	// the same stacks are still pending at the next collection
+	now = now.Add(90 * time.Second)
+	families = gatherFamilies(t, m)
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_brick_callpool_oldest_stack_age_seconds", labels: node1, expected: 90},
+		// without pending stacks there is no age
+		{name: "gluster_brick_callpool_oldest_stack_age_seconds", labels: node2, expected: 0},
+	})
+}
+
+func TestCallStackTrackerPrune(t *testing.T) {
+	tracker := newCallStackTracker()
+	first := callStackKey{hostname: "node1.example.local", path: "/mnt/gluster/gv_test", unique: 1}
+	second := callStackKey{hostname: "node1.example.local", path: "/mnt/gluster/gv_test", unique: 2}
+
+	tracker.update("gv_test", map[callStackKey]struct{}{first: {}, second: {}})
+	tracker.update("gv_old", map[callStackKey]struct{}{first: {}})
+	// answered stacks are forgotten at the next update
+	tracker.update("gv_test", map[callStackKey]struct{}{second: {}})
+	if _, ok := tracker.firstSeen["gv_test"][first]; ok {
+		t.Error("expected the answered stack to be forgotten")
+	}
+
+	// volumes that weren't collected are forgotten entirely
+	tracker.prune([]string{"gv_test"})
+	if _, ok := tracker.firstSeen["gv_old"]; ok {
+		t.Error("expected the stacks of the uncollected volume to be forgotten")
+	}
+	if len(tracker.firstSeen["gv_test"]) != 1 {
+		t.Errorf("expected the pending stack of gv_test to be kept, got %v", tracker.firstSeen["gv_test"])
+	}
+}
+
+func TestCollectTop(t *testing.T) {
+	brick := map[string]string{"volume": "gv_test", "brick": "node1.example.local:/mnt/gluster/gv_test"}
+
+	viper.Set("collector_top", true)
+	viper.Set("collector_top_ops", "open,read")
+	viper.Set("collector_top_perf", true)
+	m, runner := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+
+	if value := metricValue(findMetric(families["gluster_brick_top_open_fds"], brick)); value != 12 {
+		t.Errorf("expected 12 open fds and got %v", value)
+	}
+	brick["op"], brick["file"] = "read", "/data/db/index.db"
+	if value := metricValue(findMetric(families["gluster_brick_top_file_count"], brick)); value != 48211 {
+		t.Errorf("expected the reads of index.db and got %v", value)
+	}
+	brick["op"] = "read-perf"
+	if value := metricValue(findMetric(families["gluster_brick_top_file_throughput_bytes_per_second"], brick)); value != 412.5e6 {
+		t.Errorf("expected the read throughput of index.db in bytes and got %v", value)
+	}
+	if len(families["gluster_brick_top_file_count"].GetMetric()) != 8 {
+		t.Errorf("expected a series per brick, op and file, got %v", families["gluster_brick_top_file_count"].GetMetric())
+	}
+
+	var commands [][]string
+	for _, call := range runner.Calls() {
+		if slices.Contains(call.Args, "top") {
+			commands = append(commands, call.Args)
+		}
+	}
+	if len(commands) != 8 || !slices.Equal(commands[0], []string{"volume", "top", "gv_cluster", "open", "list-cnt", "10", "--xml"}) {
+		t.Errorf("expected the configured top operations with list-cnt, got %v", commands)
+	}
+}
+
+func TestTopConfig(t *testing.T) {
+	top, err := newTopMetrics("", false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(top.ops, gluster.TopOps) || top.listCount != defaultTopListCount {
+		t.Errorf("expected the default operations and list count, got %v and %v", top.ops, top.listCount)
+	}
+	if _, err := newTopMetrics("read,stat", false, 10); err == nil {
+		t.Error("expected an error for an unknown operation")
+	}
+	if _, err := newTopMetrics("read", false, gluster.MaxTopListCount+1); err == nil {
+		t.Error("expected an error for a list count above the limit")
+	}
+
+	top, err = newTopMetrics("read, write,read", true, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{gluster.TopRead, gluster.TopWrite, gluster.TopReadPerf, gluster.TopWritePerf}; !slices.Equal(top.ops, expected) {
+		t.Errorf("expected %v without duplicates, got %v", expected, top.ops)
+	}
+	if _, err := newTopMetrics("read-perf", true, 10); err == nil {
+		t.Error("expected an error for a perf operation outside of --collector.top.perf")
+	}
+}
+
+// usageRunner answers "heal info summary" like gluster versions without it do
+// volumeInfoFailingRunner fails "gluster volume info" like a timed out glusterd
+type volumeInfoFailingRunner struct {
+	gluster.Runner
+}
+
+func (r volumeInfoFailingRunner) Run(ctx context.Context, args ...string) (gluster.Result, error) {
+	if len(args) > 1 && args[0] == "volume" && args[1] == "info" {
+		return gluster.Result{ExitCode: 1, Stderr: []byte("Error : Request timed out\n")}, nil
+	}
+	return r.Runner.Run(ctx, args...)
+}
+
+func TestCollectWithoutVolumeInfo(t *testing.T) {
+	node1 := map[string]string{"volume": "gv_test", "brick": "node1.example.com:/mnt/gluster/gv_test"}
+
+	// all volumes are read from the volume list
+	m, runner := newTestMetricsWithRunner(t, volumeInfoFailingRunner{Runner: gluster.NewFixtureRunner(fixturesDir)})
+	families := gatherFamilies(t, m)
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_up", expected: 0},
+		{name: "gluster_heal_entries", labels: node1, expected: 5},
+	})
+	if !slices.ContainsFunc(runner.Calls(), listsVolumes) {
+		t.Error("expected the volumes to be listed")
+	}
+
+	// configured volumes are queried without listing the volumes
+	m, runner = newTestMetricsWithRunner(t, volumeInfoFailingRunner{Runner: gluster.NewFixtureRunner(fixturesDir)})
+	m.volumes = []string{"gv_test"}
+	families = gatherFamilies(t, m)
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_heal_entries", labels: node1, expected: 5},
+	})
+	if slices.ContainsFunc(runner.Calls(), listsVolumes) {
+		t.Error("expected the configured volumes to be used")
+	}
+}
+
+func listsVolumes(call gluster.Call) bool {
+	return len(call.Args) > 1 && call.Args[0] == "volume" && call.Args[1] == "list"
+}
+
+type usageRunner struct {
+	gluster.Runner
+}
+
+func (r usageRunner) Run(ctx context.Context, args ...string) (gluster.Result, error) {
+	if slices.Contains(args, "summary") {
+		return gluster.Result{ExitCode: 1, Stderr: []byte("Usage:\nvolume heal <VOLNAME> [enable | disable | full |statistics [heal-count [replica <HOSTNAME:BRICKNAME>]] |info [split-brain] ...]\n")}, nil
+	}
+	return r.Runner.Run(ctx, args...)
+}
+
+func TestCollectHealInfo(t *testing.T) {
+	node1 := map[string]string{"volume": "gv_test", "brick": "node1.example.com:/mnt/gluster/gv_test"}
+	node3 := map[string]string{"volume": "gv_test", "brick": "node3.example.com:/mnt/gluster/gv_test"}
+
+	m, runner := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_heal_entries", labels: node1, expected: 5},
+		{name: "gluster_heal_pending_entries", labels: node1, expected: 2},
+		{name: "gluster_heal_split_brain_entries", labels: node1, expected: 2},
+		{name: "gluster_heal_possibly_healing_entries", labels: node1, expected: 1},
+		{name: "gluster_heal_brick_connected", labels: map[string]string{"brick": node1["brick"], "status": "Connected"}, expected: 1},
+		{name: "gluster_heal_brick_connected", labels: map[string]string{"brick": node3["brick"], "status": "Transport endpoint is not connected"}, expected: 0},
+	})
+	if findMetric(families["gluster_heal_pending_entries"], node3) != nil {
+		t.Error("expected no pending entries for a disconnected brick")
+	}
+	if family := families["gluster_heal_info_files_count"]; family.GetType() != dto.MetricType_GAUGE {
+		t.Errorf("expected gluster_heal_info_files_count to be a gauge, got %v", family.GetType())
+	}
+	for _, call := range runner.Calls() {
+		if slices.Equal(call.Args[3:], []string{"info", "split-brain", "--xml"}) {
+			t.Errorf("expected the split-brain entries to be taken from the summary, got %v", call.Args)
+		}
+	}
+}
+
+func TestCollectHealInfoWithoutSummary(t *testing.T) {
+	m, runner := newTestMetricsWithRunner(t, usageRunner{overrideRunner{
+		Runner: gluster.NewFixtureRunner(fixturesDir),
+		files:  map[string]string{"gluster_volume_heal_gv_test_info.xml": "gluster_volume_heal_info_err_node1.xml"},
+	}})
+	families := gatherFamilies(t, m)
+
+	node1 := map[string]string{"volume": "gv_test", "brick": "node1.example.com:/mnt/gluster/gv_test"}
+	assertMetrics(t, families, []metricCase{
+		// the entries of the connected brick while the others are down
+		{name: "gluster_heal_info_files_count", labels: map[string]string{"volume": "gv_test"}, expected: 5},
+		{name: "gluster_heal_pending_entries", labels: node1, expected: 5},
+		{name: "gluster_heal_brick_connected", labels: map[string]string{"volume": "gv_test", "brick": "node2.example.com:/mnt/gluster/gv_test"}, expected: 0},
+		// from heal info split-brain
+		{name: "gluster_heal_split_brain_entries", labels: node1, expected: 2},
+	})
+
+	// the summary isn't tried again once gluster answered with its usage
+	gatherFamilies(t, m)
+	summaries := 0
+	for _, call := range runner.Calls() {
+		if slices.Contains(call.Args, "summary") {
+			summaries++
+		}
+	}
+	if summaries != 1 {
+		t.Errorf("expected heal info summary to be run once and got %v", summaries)
+	}
+}
+
+func TestCollectHealStatistics(t *testing.T) {
+	viper.Set("collector_heal_statistics", true)
+	m, runner := newTestMetrics(t)
+	// the node prints the crawl times in its own time zone
+	location := time.FixedZone("UTC+5", 5*60*60)
+	m.client.Location = location
+	families := gatherFamilies(t, m)
+
+	index := map[string]string{"volume": "gv_test", "hostname": "node1.example.com", "brick_index": "0", "type": "INDEX"}
+	full := map[string]string{"volume": "gv_test", "hostname": "node1.example.com", "brick_index": "0", "type": "FULL"}
+	start := time.Date(2024, 1, 15, 10, 10, 3, 0, location)
+	assertMetrics(t, families, []metricCase{
+		// the start of the latest index crawl
+		{name: "gluster_heal_last_crawl_start_timestamp_seconds", labels: index, expected: float64(start.Unix())},
+		{name: "gluster_heal_crawls", labels: index, expected: 2},
+		{name: "gluster_heal_last_crawl_healed_entries", labels: index, expected: 3},
+		{name: "gluster_heal_last_crawl_split_brain_entries", labels: index, expected: 2},
+		{name: "gluster_heal_crawl_in_progress", labels: index, expected: 0},
+		{name: "gluster_heal_crawl_in_progress", labels: full, expected: 1},
+		{name: "gluster_heal_last_crawl_healed_entries", labels: full, expected: 418},
+		{name: "gluster_heal_count_entries", labels: map[string]string{"volume": "gv_test", "brick": "node1.example.com:/mnt/gluster/gv_test"}, expected: 5},
+	})
+
+	if findMetric(families["gluster_heal_last_crawl_end_timestamp_seconds"], full) != nil {
+		t.Error("expected no end timestamp for a running crawl")
+	}
+
+	for _, call := range runner.Calls() {
+		if slices.Contains(call.Args, "statistics") && slices.Contains(call.Args, "--xml") {
+			t.Errorf("heal statistics has no xml output, got %v", call.Args)
+		}
+	}
+}
+
+func TestCollectGeoRep(t *testing.T) {
+	viper.Set("collector_georep", true)
+	m, _ := newTestMetrics(t)
+	location := time.FixedZone("UTC-3", -3*60*60)
+	m.client.Location = location
+	lastSynced := time.Date(2024, 1, 15, 10, 0, 0, 0, location)
+	m.geoRep.now = func() time.Time { return lastSynced.Add(5 * time.Minute) }
+	families := gatherFamilies(t, m)
+
+	node1 := map[string]string{"master_volume": "gv_test", "slave_host": "dr1.example.com", "slave_volume": "gv_dr", "master_brick": "node1.example.local:/mnt/gluster/gv_test"}
+	node2 := map[string]string{"master_volume": "gv_test", "master_brick": "node2.example.local:/mnt/gluster/gv_test"}
+	cluster := map[string]string{"master_volume": "gv_cluster", "slave_volume": "gv_cluster_dr"}
+	with := func(labels map[string]string, name, value string) map[string]string {
+		res := map[string]string{name: value}
+		for k, v := range labels {
+			res[k] = v
+		}
+		return res
+	}
+
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_georep_worker_status", labels: with(node1, "status", "Active"), expected: 1},
+		{name: "gluster_georep_worker_status", labels: with(node1, "status", "Faulty"), expected: 0},
+		{name: "gluster_georep_worker_status", labels: with(node2, "status", "Passive"), expected: 1},
+		{name: "gluster_georep_worker_status", labels: with(cluster, "status", "Faulty"), expected: 1},
+		{name: "gluster_georep_crawl_status", labels: with(node1, "crawl_status", "Changelog Crawl"), expected: 1},
+		{name: "gluster_georep_last_synced_timestamp_seconds", labels: node1, expected: float64(lastSynced.Unix())},
+		{name: "gluster_georep_lag_seconds", labels: node1, expected: 300},
+		{name: "gluster_georep_pending", labels: with(node1, "type", "data"), expected: 340},
+		{name: "gluster_georep_failures", labels: cluster, expected: 3},
+		{name: "gluster_georep_checkpoint_completed", labels: node1, expected: 0},
+		{name: "gluster_georep_checkpoint_completed", labels: cluster, expected: 1},
+	})
+
+	if findMetric(families["gluster_georep_pending"], node2) != nil || findMetric(families["gluster_georep_last_synced_timestamp_seconds"], node2) != nil {
+		t.Error("expected no values for the passive worker")
+	}
+	if findMetric(families["gluster_georep_checkpoint_completion_timestamp_seconds"], node1) != nil {
+		t.Error("expected no completion time for an incomplete checkpoint")
+	}
+}
+
+func TestCollectRebalance(t *testing.T) {
+	viper.Set("collector_rebalance", true)
+	m, _ := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+
+	rebalance := map[string]string{"volume": "gv_test", "task": "rebalance", "node": "localhost"}
+	removeBrick := map[string]string{"volume": "gv_test", "task": "remove-brick", "node": "host4.example.local"}
+
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_volume_task_info", labels: map[string]string{"volume": "gv_test", "type": "Rebalance", "id": "3f1a9c2e-5b7d-4e0f-9a8b-1c2d3e4f5a6b", "status": "in progress"}, expected: 1},
+		{name: "gluster_volume_task_info", labels: map[string]string{"volume": "gv_test", "type": "Remove brick", "status": "completed"}, expected: 1},
+		{name: "gluster_rebalance_files_migrated", labels: rebalance, expected: 18230},
+		{name: "gluster_rebalance_files_scanned", labels: rebalance, expected: 402112},
+		{name: "gluster_rebalance_files_skipped", labels: rebalance, expected: 117},
+		{name: "gluster_rebalance_files_failed", labels: rebalance, expected: 2},
+		{name: "gluster_rebalance_moved_bytes", labels: rebalance, expected: 96488591360},
+		{name: "gluster_rebalance_runtime_seconds", labels: rebalance, expected: 86412},
+		{name: "gluster_rebalance_status", labels: rebalance, expected: 1},
+		{name: "gluster_rebalance_time_left_seconds", labels: rebalance, expected: 52000},
+		{name: "gluster_rebalance_files_migrated", labels: removeBrick, expected: 5120},
+		{name: "gluster_rebalance_status", labels: removeBrick, expected: 3},
+	})
+
+	if findMetric(families["gluster_rebalance_time_left_seconds"], removeBrick) != nil {
+		t.Error("remove-brick doesn't estimate the time left")
+	}
+	if findMetric(families["gluster_volume_task_info"], map[string]string{"volume": "gv_cluster"}) != nil {
+		t.Error("gv_cluster has no tasks")
+	}
+}
+
+func TestCollectVolumeSnapshots(t *testing.T) {
+	viper.Set("collector_snapshots", true)
+	m, _ := newTestMetrics(t)
+	location := time.FixedZone("UTC+2", 2*60*60)
+	m.client.Location = location
+	newest := time.Date(2024, 1, 15, 2, 0, 0, 0, location)
+	m.volumeSnapshots.now = func() time.Time { return newest.Add(3 * time.Hour) }
+	families := gatherFamilies(t, m)
+
+	gvTest := map[string]string{"volume": "gv_test"}
+	gvCluster := map[string]string{"volume": "gv_cluster"}
+	older := map[string]string{"volume": "gv_test", "snapshot": "daily_GMT-2024.01.14-02.00.00"}
+	newer := map[string]string{"volume": "gv_test", "snapshot": "daily_GMT-2024.01.15-02.00.00"}
+
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_snapshot_count", labels: gvTest, expected: 2},
+		{name: "gluster_snapshot_count", labels: gvCluster, expected: 0},
+		{name: "gluster_snapshot_hard_limit", labels: gvTest, expected: 10},
+		{name: "gluster_snapshot_soft_limit", labels: gvTest, expected: 9},
+		{name: "gluster_snapshot_hard_limit", labels: gvCluster, expected: 256},
+		{name: "gluster_snapshot_activated", labels: older, expected: 0},
+		{name: "gluster_snapshot_activated", labels: newer, expected: 1},
+		{name: "gluster_snapshot_created_timestamp_seconds", labels: newer, expected: float64(newest.Unix())},
+		{name: "gluster_snapshot_newest_age_seconds", labels: gvTest, expected: 3 * 3600},
+	})
+
+	if findMetric(families["gluster_snapshot_newest_age_seconds"], gvCluster) != nil {
+		t.Error("gv_cluster has no snapshot to be aged")
+	}
+}
+
+// bitrotDisabledRunner answers the bitrot commands of gv_cluster like volumes without bitrot detection do
+type bitrotDisabledRunner struct {
+	gluster.Runner
+}
+
+func (r bitrotDisabledRunner) Run(ctx context.Context, args ...string) (gluster.Result, error) {
+	if slices.Contains(args, "bitrot") && slices.Contains(args, "gv_cluster") {
+		return gluster.Result{ExitCode: 1, Stderr: []byte("Bitrot command failed : Bitrot is not enabled on volume gv_cluster\n")}, nil
+	}
+	return r.Runner.Run(ctx, args...)
+}
+
+func TestCollectBitrot(t *testing.T) {
+	viper.Set("collector_bitrot", true)
+	m, _ := newTestMetricsWithRunner(t, bitrotDisabledRunner{Runner: gluster.NewFixtureRunner(fixturesDir)})
+	location := time.FixedZone("UTC-7", -7*60*60)
+	m.client.Location = location
+	families := gatherFamilies(t, m)
+
+	localhost := map[string]string{"volume": "gv_test", "node": "localhost"}
+	pending := map[string]string{"volume": "gv_test", "node": "node2.example.local"}
+
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_bitrot_enabled", labels: map[string]string{"volume": "gv_test"}, expected: 1},
+		{name: "gluster_bitrot_enabled", labels: map[string]string{"volume": "gv_cluster"}, expected: 0},
+		{name: "gluster_bitrot_scrubbed_files", labels: localhost, expected: 152340},
+		{name: "gluster_bitrot_skipped_files", labels: localhost, expected: 12},
+		{name: "gluster_bitrot_last_scrub_completed_timestamp_seconds", labels: localhost, expected: float64(time.Date(2024, 1, 15, 3, 12, 45, 0, location).Unix())},
+		{name: "gluster_bitrot_last_scrub_duration_seconds", labels: localhost, expected: 4354},
+		{name: "gluster_bitrot_scrub_errors", labels: localhost, expected: 2},
+		{name: "gluster_bitrot_corrupted_objects", labels: localhost, expected: 2},
+		{name: "gluster_bitrot_corrupted_objects", labels: pending, expected: 0},
+	})
+
+	if findMetric(families["gluster_bitrot_last_scrub_completed_timestamp_seconds"], pending) != nil {
+		t.Error("node2 hasn't completed a scrub yet")
+	}
+	if findMetric(families["gluster_bitrot_scrubbed_files"], map[string]string{"volume": "gv_cluster"}) != nil {
+		t.Error("gv_cluster has bitrot detection disabled")
+	}
+}
+
+func TestCollectVolumeOptions(t *testing.T) {
+	viper.Set("collector_volume_options", "server.ssl,auth.allow,cluster.quorum-type")
+	viper.Set("collector_volume_options_policy", filepath.Join(fixturesDir, "volume_option_policy.yml"))
+	m, runner := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_volume_option_info", labels: map[string]string{"volume": "gv_test", "option": "server.ssl", "value": "on"}, expected: 1},
+		{name: "gluster_volume_option_info", labels: map[string]string{"volume": "gv_cluster", "option": "auth.allow"}, expected: 1},
+		{name: "gluster_volume_option_drift", labels: map[string]string{"volume": "gv_test", "option": "server.ssl", "expected": "on", "actual": "on"}, expected: 0},
+		{name: "gluster_volume_option_drift", labels: map[string]string{"volume": "gv_test", "option": "client.ssl", "expected": "yes", "actual": "on"}, expected: 0},
+		// options that aren't set are compared by their default
+		{name: "gluster_volume_option_drift", labels: map[string]string{"volume": "gv_test", "option": "cluster.quorum-type", "expected": "auto", "actual": "auto"}, expected: 0},
+		{name: "gluster_volume_option_drift", labels: map[string]string{"volume": "gv_test", "option": "features.shard", "expected": "on", "actual": "off"}, expected: 1},
+		{name: "gluster_volume_option_drift", labels: map[string]string{"volume": "gv_cluster", "option": "nfs.disable", "expected": "off", "actual": "on"}, expected: 1},
+	})
+
+	if findMetric(families["gluster_volume_option_info"], map[string]string{"option": "nfs.disable"}) != nil {
+		t.Error("nfs.disable isn't allowlisted")
+	}
+	if findMetric(families["gluster_volume_option_drift"], map[string]string{"volume": "gv_cluster", "option": "cluster.quorum-type"}) != nil {
+		t.Error("the quorum type is only expected on gv_test")
+	}
+	for _, call := range runner.Calls() {
+		if slices.Contains(call.Args, "get") && slices.Contains(call.Args, "gv_cluster") {
+			t.Errorf("all options of the policy are set on gv_cluster, got %v", call.Args)
+		}
+	}
+}
+
+func TestVolumeOptionPolicyMissing(t *testing.T) {
+	if _, err := newVolumeOptionMetrics("", filepath.Join(fixturesDir, "missing.yml")); err == nil {
+		t.Error("expected an error for a missing policy file")
+	}
+}
+
+func TestCollectVolumeInfo(t *testing.T) {
+	m, _ := newTestMetrics(t)
+	families := gatherFamilies(t, m)
+
+	gvTest := map[string]string{"volume": "gv_test"}
+	assertMetrics(t, families, []metricCase{
+		{name: "gluster_volume_info", labels: map[string]string{"volume": "gv_test", "id": "592c9a08-3b02-482f-ab40-87372760cb47", "type": "Replicate", "transport": "tcp"}, expected: 1},
+		{name: "gluster_volume_distribute_count", labels: gvTest, expected: 1},
+		{name: "gluster_volume_replica_count", labels: gvTest, expected: 4},
+		{name: "gluster_volume_arbiter_count", labels: gvTest, expected: 0},
+		{name: "gluster_volume_disperse_count", labels: gvTest, expected: 0},
+		{name: "gluster_volume_stripe_count", labels: gvTest, expected: 1},
+		{name: "gluster_volume_snapshot_count", labels: gvTest, expected: 0},
+	})
+}
@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"os"
+	"slices"
+	"strings"
+)
+
+// optionPolicy is the desired state of the volume options, read from a
+// yaml file. Options of volumes override the ones of all volumes.
+//
+//	all:
+//	  server.ssl: "on"
+//	volumes:
+//	  gv_test:
+//	    cluster.quorum-type: auto
+type optionPolicy struct {
+	All     map[string]string            `yaml:"all"`
+	Volumes map[string]map[string]string `yaml:"volumes"`
+}
+
+// expected returns the desired options of volume
+func (p optionPolicy) expected(volume string) map[string]string {
+	options := map[string]string{}
+	for name, value := range p.All {
+		options[name] = value
+	}
+	for name, value := range p.Volumes[volume] {
+		options[name] = value
+	}
+	return options
+}
+
+func loadOptionPolicy(path string) (*optionPolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read volume option policy: %w", err)
+	}
+	var policy optionPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("couldn't parse volume option policy %v: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// volumeOptionMetrics are read from the options of "gluster volume info",
+// which only lists the options set on a volume
+type volumeOptionMetrics struct {
+	allowlist []string
+	policy    *optionPolicy
+
+	info  *prometheus.Desc
+	drift *prometheus.Desc
+}
+
+func newVolumeOptionMetrics(options string, policyPath string) (volumeOptionMetrics, error) {
+	var allowlist []string
+	if options == "" {
+		allowlist = append(allowlist, gluster.DefaultVolumeOptions...)
+	}
+	for _, option := range strings.Split(options, ",") {
+		if option = strings.TrimSpace(option); option != "" {
+			allowlist = append(allowlist, option)
+		}
+	}
+
+	policy, err := loadOptionPolicy(policyPath)
+	if err != nil {
+		return volumeOptionMetrics{}, err
+	}
+
+	return volumeOptionMetrics{
+		allowlist: allowlist,
+		policy:    policy,
+
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_option_info"),
+			"Value of an allowlisted option set on the volume.",
+			[]string{"volume", "option", "value"}, nil,
+		),
+
+		drift: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_option_drift"),
+			"Whether the effective option of the volume differs from the policy, options that aren't set are compared by their default.",
+			[]string{"volume", "option", "expected", "actual"}, nil,
+		),
+	}, nil
+}
+
+func (c volumeOptionMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.drift
+}
+
+// collectVolumeOptions exports the allowlisted options of volume and compares
+// its options to the policy. Options that aren't set on the volume are
+// compared by their default from "gluster volume get".
+func (m *Metrics) collectVolumeOptions(ctx context.Context, volume gluster.Volume, ch chan<- prometheus.Metric) {
+	for _, option := range volume.Options {
+		if !slices.Contains(m.volumeOptions.allowlist, option.Name) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			m.volumeOptions.info, prometheus.GaugeValue, 1, volume.Name, option.Name, option.Value,
+		)
+	}
+
+	if m.volumeOptions.policy == nil {
+		return
+	}
+	// the effective options are only fetched once an option isn't set
+	var effective *gluster.VolumeGetOptsXML
+	fetched := false
+	for name, expected := range m.volumeOptions.policy.expected(volume.Name) {
+		actual, ok := volume.Option(name)
+		if !ok {
+			if !fetched {
+				fetched = true
+				options, err := m.client.GetVolumeOptions(ctx, volume.Name)
+				if err != nil {
+					zap.L().Sugar().Errorf("couldn't get options of volume %v: %v", volume.Name, err)
+				} else {
+					effective = &options
+				}
+			}
+			if effective == nil {
+				continue
+			}
+			if actual, ok = effective.Option(name); !ok {
+				zap.L().Sugar().Warnf("volume %v has no option %v", volume.Name, name)
+				continue
+			}
+		}
+
+		drift := 1.0
+		if gluster.OptionValueEqual(expected, actual) {
+			drift = 0
+		}
+		ch <- prometheus.MustNewConstMetric(
+			m.volumeOptions.drift, prometheus.GaugeValue, drift, volume.Name, name, expected, actual,
+		)
+	}
+}
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"context"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// rebalanceMetrics are read from "gluster volume status {volume} tasks",
+// "gluster volume rebalance {volume} status" and "gluster volume remove-brick {volume} {bricks} status"
+type rebalanceMetrics struct {
+	taskInfo      *prometheus.Desc
+	filesMigrated *prometheus.Desc
+	filesScanned  *prometheus.Desc
+	filesSkipped  *prometheus.Desc
+	filesFailed   *prometheus.Desc
+	movedBytes    *prometheus.Desc
+	runtime       *prometheus.Desc
+	status        *prometheus.Desc
+	timeLeft      *prometheus.Desc
+}
+
+func newRebalanceMetrics() rebalanceMetrics {
+	// task is rebalance or remove-brick, node is localhost for the node answering the command
+	labels := []string{"volume", "task", "node", "node_id"}
+
+	return rebalanceMetrics{
+		taskInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_task_info"),
+			"Rebalance and remove-brick tasks of the volume with their ID and status.",
+			[]string{"volume", "type", "id", "status"}, nil,
+		),
+
+		filesMigrated: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_files_migrated"),
+			"Files the node migrated in the current task.",
+			labels, nil,
+		),
+
+		filesScanned: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_files_scanned"),
+			"Files the node looked up in the current task.",
+			labels, nil,
+		),
+
+		filesSkipped: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_files_skipped"),
+			"Files the node skipped in the current task, e.g. because the target had less free space.",
+			labels, nil,
+		),
+
+		filesFailed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_files_failed"),
+			"Files the node failed to migrate in the current task.",
+			labels, nil,
+		),
+
+		movedBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_moved_bytes"),
+			"Bytes the node moved in the current task.",
+			labels, nil,
+		),
+
+		runtime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_runtime_seconds"),
+			"Seconds the current task has been running on the node.",
+			labels, nil,
+		),
+
+		status: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_status"),
+			"Status of the current task on the node: 0 not started, 1 in progress, 2 stopped, 3 completed, 4 failed, "+
+				"5 fix-layout in progress, 6 fix-layout stopped, 7 fix-layout completed, 8 fix-layout failed.",
+			labels, nil,
+		),
+
+		timeLeft: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "rebalance_time_left_seconds"),
+			"Estimated seconds until the current task completes on the node, only reported by gluster versions that estimate it.",
+			labels, nil,
+		),
+	}
+}
+
+func (c rebalanceMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.taskInfo
+	ch <- c.filesMigrated
+	ch <- c.filesScanned
+	ch <- c.filesSkipped
+	ch <- c.filesFailed
+	ch <- c.movedBytes
+	ch <- c.runtime
+	ch <- c.status
+	ch <- c.timeLeft
+}
+
+// collectRebalance reads the tasks of volume and the progress of its
+// rebalance and remove-brick tasks. The status commands fail without a task,
+// so they are only run for the listed ones.
+func (m *Metrics) collectRebalance(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	tasks, err := m.client.GetVolumeStatusTasks(ctx, volume)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get tasks of volume %v: %v", volume, err)
+		return
+	}
+
+	for _, vol := range tasks.VolStatus.Volumes.Volume {
+		for _, task := range vol.Tasks.Task {
+			ch <- prometheus.MustNewConstMetric(
+				m.rebalance.taskInfo, prometheus.GaugeValue, 1, volume, task.Type, task.ID, task.StatusStr,
+			)
+
+			switch task.Type {
+			case gluster.TaskRebalance:
+				rebalance, err := m.client.GetVolumeRebalanceStatus(ctx, volume)
+				if err != nil {
+					zap.L().Sugar().Errorf("couldn't get rebalance status of volume %v: %v", volume, err)
+					continue
+				}
+				m.collectRebalanceNodes(volume, "rebalance", rebalance.VolRebalance, ch)
+			case gluster.TaskRemoveBrick:
+				removeBrick, err := m.client.GetVolumeRemoveBrickStatus(ctx, volume, task.Params.Brick)
+				if err != nil {
+					zap.L().Sugar().Errorf("couldn't get remove-brick status of volume %v: %v", volume, err)
+					continue
+				}
+				m.collectRebalanceNodes(volume, "remove-brick", removeBrick.VolRemoveBrick, ch)
+			}
+		}
+	}
+}
+
+func (m *Metrics) collectRebalanceNodes(volume string, task string, status gluster.RebalanceStatus, ch chan<- prometheus.Metric) {
+	for _, node := range status.Node {
+		labels := []string{volume, task, node.NodeName, node.ID}
+
+		ch <- prometheus.MustNewConstMetric(
+			m.rebalance.filesMigrated, prometheus.GaugeValue, float64(node.Files), labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.rebalance.filesScanned, prometheus.GaugeValue, float64(node.Lookups), labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.rebalance.filesSkipped, prometheus.GaugeValue, float64(node.Skipped), labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.rebalance.filesFailed, prometheus.GaugeValue, float64(node.Failures), labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.rebalance.movedBytes, prometheus.GaugeValue, float64(node.Size), labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.rebalance.runtime, prometheus.GaugeValue, node.Runtime, labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.rebalance.status, prometheus.GaugeValue, float64(node.Status), labels...,
+		)
+		if node.TimeLeft != nil {
+			ch <- prometheus.MustNewConstMetric(
+				m.rebalance.timeLeft, prometheus.GaugeValue, float64(*node.TimeLeft), labels...,
+			)
+		}
+	}
+}
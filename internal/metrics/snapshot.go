@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"time"
+)
+
+// snapshot is the immutable result of a background collection
+type snapshot struct {
+	metrics     []prometheus.Metric
+	collectedAt time.Time
+	lastSuccess time.Time
+}
+
+// Run collects in the background every collector interval until ctx is done,
+// scrapes are then served from the latest snapshot. Run returns right away
+// when no interval is configured.
+func (m *Metrics) Run(ctx context.Context) {
+	if m.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.refreshSnapshot(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshSnapshot runs a full collection and replaces the current snapshot
+func (m *Metrics) refreshSnapshot(ctx context.Context) {
+	start := time.Now()
+	ch := make(chan prometheus.Metric)
+	collected := make(chan []prometheus.Metric)
+	go func() {
+		var metrics []prometheus.Metric
+		for metric := range ch {
+			metrics = append(metrics, metric)
+		}
+		collected <- metrics
+	}()
+
+	up := m.collect(ctx, ch)
+	close(ch)
+
+	next := &snapshot{metrics: <-collected, collectedAt: time.Now()}
+	if up {
+		next.lastSuccess = next.collectedAt
+	} else if previous := m.snapshot.Load(); previous != nil {
+		next.lastSuccess = previous.lastSuccess
+	}
+	m.snapshot.Store(next)
+	zap.L().Sugar().Debugf("collected snapshot with %v metrics in %v", len(next.metrics), time.Since(start))
+}
+
+// collectSnapshot sends the latest snapshot and its staleness to ch
+func (m *Metrics) collectSnapshot(ch chan<- prometheus.Metric) {
+	current := m.snapshot.Load()
+	if current == nil {
+		return
+	}
+
+	for _, metric := range current.metrics {
+		ch <- metric
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		m.snapshotAge, prometheus.GaugeValue, time.Since(current.collectedAt).Seconds(),
+	)
+	if !current.lastSuccess.IsZero() {
+		ch <- prometheus.MustNewConstMetric(
+			m.lastSuccess, prometheus.GaugeValue, float64(current.lastSuccess.UnixNano())/1e9,
+		)
+	}
+}
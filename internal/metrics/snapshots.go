@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"context"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"slices"
+	"time"
+)
+
+// snapshotTimeLayout is the format of the creation time of a snapshot, in the
+// local time of the node, see gluster.Client.Location
+const snapshotTimeLayout = "2006-01-02 15:04:05"
+
+// volumeSnapshotMetrics are read from "gluster snapshot info" and "gluster snapshot config".
+// They are named after gluster's snapshots, not the background collected
+// snapshot served to scrapes.
+type volumeSnapshotMetrics struct {
+	count     *prometheus.Desc
+	hardLimit *prometheus.Desc
+	softLimit *prometheus.Desc
+	created   *prometheus.Desc
+	activated *prometheus.Desc
+	newestAge *prometheus.Desc
+
+	now func() time.Time
+}
+
+func newVolumeSnapshotMetrics() volumeSnapshotMetrics {
+	return volumeSnapshotMetrics{
+		count: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "snapshot_count"),
+			"Snapshots of the volume.",
+			[]string{"volume"}, nil,
+		),
+
+		hardLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "snapshot_hard_limit"),
+			"Effective snap-max-hard-limit of the volume, the lower of the volume and the system limit. No snapshot can be created beyond it.",
+			[]string{"volume"}, nil,
+		),
+
+		softLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "snapshot_soft_limit"),
+			"Snapshots of the volume from which on gluster warns or, with auto-delete, deletes the oldest snapshot.",
+			[]string{"volume"}, nil,
+		),
+
+		created: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "snapshot_created_timestamp_seconds"),
+			"Creation time of the snapshot.",
+			[]string{"volume", "snapshot"}, nil,
+		),
+
+		activated: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "snapshot_activated"),
+			"Whether the snapshot is activated and can be mounted.",
+			[]string{"volume", "snapshot"}, nil,
+		),
+
+		newestAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "snapshot_newest_age_seconds"),
+			"Seconds since the newest snapshot of the volume was created.",
+			[]string{"volume"}, nil,
+		),
+
+		now: time.Now,
+	}
+}
+
+func (c volumeSnapshotMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.count
+	ch <- c.hardLimit
+	ch <- c.softLimit
+	ch <- c.created
+	ch <- c.activated
+	ch <- c.newestAge
+}
+
+// collectVolumeSnapshots reads the snapshots and snapshot limits of the
+// configured volumes. The config lists every volume, so volumes without
+// snapshots are reported with a count of 0.
+func (m *Metrics) collectVolumeSnapshots(ctx context.Context, ch chan<- prometheus.Metric) {
+	wanted := func(volume string) bool {
+		return m.volumes[0] == gluster.AllVolumes || slices.Contains(m.volumes, volume)
+	}
+
+	info, err := m.client.GetSnapshotInfo(ctx)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get snapshot info: %v", err)
+		return
+	}
+
+	now := m.volumeSnapshots.now()
+	counts := map[string]int{}
+	newest := map[string]time.Time{}
+	for _, snapshot := range info.SnapInfo.Snapshots.Snapshot {
+		created, err := time.ParseInLocation(snapshotTimeLayout, snapshot.CreateTime, m.client.Location)
+		if err != nil {
+			zap.L().Sugar().Errorf("couldn't parse creation time of snapshot %v: %v", snapshot.Name, err)
+		}
+
+		for _, snapVolume := range snapshot.SnapVolume {
+			volume := snapVolume.OriginVolume.Name
+			if !wanted(volume) {
+				continue
+			}
+			counts[volume]++
+
+			activated := 0.0
+			if snapVolume.Status == "Started" {
+				activated = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.volumeSnapshots.activated, prometheus.GaugeValue, activated, volume, snapshot.Name,
+			)
+
+			if err != nil {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				m.volumeSnapshots.created, prometheus.GaugeValue, float64(created.Unix()), volume, snapshot.Name,
+			)
+			if created.After(newest[volume]) {
+				newest[volume] = created
+			}
+		}
+	}
+
+	for volume, created := range newest {
+		ch <- prometheus.MustNewConstMetric(
+			m.volumeSnapshots.newestAge, prometheus.GaugeValue, now.Sub(created).Seconds(), volume,
+		)
+	}
+
+	config, err := m.client.GetSnapshotConfig(ctx)
+	if err != nil {
+		zap.L().Sugar().Errorf("couldn't get snapshot config: %v", err)
+		for volume, count := range counts {
+			ch <- prometheus.MustNewConstMetric(
+				m.volumeSnapshots.count, prometheus.GaugeValue, float64(count), volume,
+			)
+		}
+		return
+	}
+
+	for _, volume := range config.SnapConfig.VolumeConfig.Volume {
+		if !wanted(volume.Name) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			m.volumeSnapshots.count, prometheus.GaugeValue, float64(counts[volume.Name]), volume.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.volumeSnapshots.hardLimit, prometheus.GaugeValue, float64(volume.EffectiveHardLimit), volume.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			m.volumeSnapshots.softLimit, prometheus.GaugeValue, float64(volume.SoftLimit), volume.Name,
+		)
+	}
+}
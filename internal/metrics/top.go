@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"slices"
+	"strings"
+)
+
+// defaultTopListCount is used when no list-cnt is configured
+const defaultTopListCount = 10
+
+// topMetrics are read from "gluster volume top {volume} {op} list-cnt {listCount}"
+type topMetrics struct {
+	ops       []string
+	listCount int
+
+	fileCount      *prometheus.Desc
+	fileThroughput *prometheus.Desc
+	openFds        *prometheus.Desc
+	maxOpenFds     *prometheus.Desc
+}
+
+// newTopMetrics runs ops, a comma separated list of the counting top
+// operations, and additionally read-perf and write-perf with perf
+func newTopMetrics(ops string, perf bool, listCount int) (topMetrics, error) {
+	if listCount == 0 {
+		listCount = defaultTopListCount
+	}
+	if listCount < 1 || listCount > gluster.MaxTopListCount {
+		return topMetrics{}, fmt.Errorf("top list count has to be between 1 and %v, got %v", gluster.MaxTopListCount, listCount)
+	}
+
+	var topOps []string
+	if ops == "" {
+		topOps = append(topOps, gluster.TopOps...)
+	}
+	for _, op := range strings.Split(ops, ",") {
+		op = strings.TrimSpace(op)
+		if op == "" {
+			continue
+		}
+		if gluster.IsTopPerfOp(op) {
+			return topMetrics{}, fmt.Errorf("top operation %v is collected with --collector.top.perf", op)
+		}
+		if !gluster.IsTopOp(op) {
+			return topMetrics{}, fmt.Errorf("unknown top operation %v", op)
+		}
+		// every operation is collected once, duplicate series would fail the scrape
+		if !slices.Contains(topOps, op) {
+			topOps = append(topOps, op)
+		}
+	}
+	if perf {
+		topOps = append(topOps, gluster.TopPerfOps...)
+	}
+
+	return topMetrics{
+		ops:       topOps,
+		listCount: listCount,
+
+		fileCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_top_file_count"),
+			"How often the operation was done on one of the busiest files of the brick, limited to list-cnt files per brick.",
+			[]string{"volume", "brick", "op", "file"}, nil,
+		),
+
+		fileThroughput: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_top_file_throughput_bytes_per_second"),
+			"Throughput of one of the files of the brick with the highest read or write throughput, limited to list-cnt files per brick.",
+			[]string{"volume", "brick", "op", "file"}, nil,
+		),
+
+		openFds: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_top_open_fds"),
+			"Open file descriptors of the brick as reported by gluster volume top open.",
+			[]string{"volume", "brick"}, nil,
+		),
+
+		maxOpenFds: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "brick_top_max_open_fds"),
+			"Highest number of open file descriptors of the brick as reported by gluster volume top open.",
+			[]string{"volume", "brick"}, nil,
+		),
+	}, nil
+}
+
+func (c topMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.fileCount
+	ch <- c.fileThroughput
+	ch <- c.openFds
+	ch <- c.maxOpenFds
+}
+
+// collectTop reads the busiest files of the bricks of volume for every
+// configured top operation
+func (m *Metrics) collectTop(ctx context.Context, volume string, ch chan<- prometheus.Metric) {
+	for _, op := range m.top.ops {
+		volumeTop, err := m.client.GetVolumeTop(ctx, volume, op, m.top.listCount)
+		if err != nil {
+			zap.L().Sugar().Errorf("couldn't get top %v of volume %v: %v", op, volume, err)
+			continue
+		}
+
+		for _, brick := range volumeTop.VolTop.Brick {
+			if !m.ownBrick(brick.Name) {
+				continue
+			}
+
+			if op == gluster.TopOpen {
+				ch <- prometheus.MustNewConstMetric(
+					m.top.openFds, prometheus.GaugeValue, float64(brick.CurrentOpen), volume, brick.Name,
+				)
+				ch <- prometheus.MustNewConstMetric(
+					m.top.maxOpenFds, prometheus.GaugeValue, float64(brick.MaxOpen), volume, brick.Name,
+				)
+			}
+
+			for _, file := range brick.File {
+				if gluster.IsTopPerfOp(op) {
+					// gluster reports the throughput in MBps
+					ch <- prometheus.MustNewConstMetric(
+						m.top.fileThroughput, prometheus.GaugeValue, file.Throughput*1e6, volume, brick.Name, op, file.Filename,
+					)
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(
+					m.top.fileCount, prometheus.GaugeValue, float64(file.Count), volume, brick.Name, op, file.Filename,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"github.com/nilpntr/gluster-exporter/internal/gluster"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// volumeInfoMetrics are the layout of a volume from "gluster volume info",
+// to group and join the per volume metrics by volume type
+type volumeInfoMetrics struct {
+	info            *prometheus.Desc
+	distributeCount *prometheus.Desc
+	replicaCount    *prometheus.Desc
+	arbiterCount    *prometheus.Desc
+	disperseCount   *prometheus.Desc
+	redundancyCount *prometheus.Desc
+	stripeCount     *prometheus.Desc
+	snapshotCount   *prometheus.Desc
+}
+
+func newVolumeInfoMetrics() volumeInfoMetrics {
+	labels := []string{"volume"}
+
+	return volumeInfoMetrics{
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_info"),
+			"Type, e.g. Replicate or Distributed-Disperse, and transport of the volume.",
+			[]string{"volume", "id", "type", "transport"}, nil,
+		),
+
+		distributeCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_distribute_count"),
+			"Distribute subvolumes of the volume.",
+			labels, nil,
+		),
+
+		replicaCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_replica_count"),
+			"Replicas of every file of the volume.",
+			labels, nil,
+		),
+
+		arbiterCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_arbiter_count"),
+			"Arbiter bricks per replica set of the volume.",
+			labels, nil,
+		),
+
+		disperseCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_disperse_count"),
+			"Bricks per disperse set of the volume.",
+			labels, nil,
+		),
+
+		redundancyCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_redundancy_count"),
+			"Bricks per disperse set of the volume that can fail without losing data.",
+			labels, nil,
+		),
+
+		stripeCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_stripe_count"),
+			"Stripes of the volume, 1 unless it's a legacy striped volume.",
+			labels, nil,
+		),
+
+		snapshotCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "volume_snapshot_count"),
+			"Snapshots of the volume as reported by volume info.",
+			labels, nil,
+		),
+	}
+}
+
+func (c volumeInfoMetrics) describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.distributeCount
+	ch <- c.replicaCount
+	ch <- c.arbiterCount
+	ch <- c.disperseCount
+	ch <- c.redundancyCount
+	ch <- c.stripeCount
+	ch <- c.snapshotCount
+}
+
+// collectVolumeInfo exports the type, transport and layout of volume
+func (m *Metrics) collectVolumeInfo(volume gluster.Volume, ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		m.volumeInfo.info, prometheus.GaugeValue, 1, volume.Name, volume.ID, volume.TypeStr, volume.TransportName(),
+	)
+
+	for _, gauge := range []struct {
+		desc  *prometheus.Desc
+		value int
+	}{
+		{m.volumeInfo.distributeCount, volume.DistributeCount()},
+		{m.volumeInfo.replicaCount, volume.ReplicaCount},
+		{m.volumeInfo.arbiterCount, volume.ArbiterCount},
+		{m.volumeInfo.disperseCount, volume.DisperseCount},
+		{m.volumeInfo.redundancyCount, volume.RedundancyCount},
+		{m.volumeInfo.stripeCount, volume.StripeCount},
+		{m.volumeInfo.snapshotCount, volume.SnapshotCount},
+	} {
+		ch <- prometheus.MustNewConstMetric(
+			gauge.desc, prometheus.GaugeValue, float64(gauge.value), volume.Name,
+		)
+	}
+}
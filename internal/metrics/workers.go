@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+)
+
+// forEachVolume runs fn for every volume on at most m.concurrency workers.
+// The metrics of each volume are buffered and sent to ch in the order of
+// volumes once all workers are done, so the output doesn't depend on timing.
+func (m *Metrics) forEachVolume(volumes []string, ch chan<- prometheus.Metric, fn func(volume string, ch chan<- prometheus.Metric)) {
+	results := make([][]prometheus.Metric, len(volumes))
+	sem := make(chan struct{}, max(m.concurrency, 1))
+	var wg sync.WaitGroup
+	for i, volume := range volumes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			volumeCh := make(chan prometheus.Metric)
+			done := make(chan struct{})
+			go func() {
+				for metric := range volumeCh {
+					results[i] = append(results[i], metric)
+				}
+				close(done)
+			}()
+			fn(volume, volumeCh)
+			close(volumeCh)
+			<-done
+		}()
+	}
+	wg.Wait()
+
+	for _, metrics := range results {
+		for _, metric := range metrics {
+			ch <- metric
+		}
+	}
+}
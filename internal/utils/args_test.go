@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{input: "", expected: nil},
+		{input: "sudo -n", expected: []string{"sudo", "-n"}},
+		{input: "  docker   exec glusterd ", expected: []string{"docker", "exec", "glusterd"}},
+		{input: `docker exec "gluster server"`, expected: []string{"docker", "exec", "gluster server"}},
+		{input: `ssh 'node 1' a\ b ""`, expected: []string{"ssh", "node 1", "a b", ""}},
+		{input: `kubectl exec -n "$NS" pod`, expected: []string{"kubectl", "exec", "-n", "$NS", "pod"}},
+		{input: `sudo "-n`, wantErr: true},
+	}
+	for _, c := range tests {
+		args, err := SplitArgs(c.input)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("expected an error for %q", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", c.input, err)
+			continue
+		}
+		if !slices.Equal(args, c.expected) {
+			t.Errorf("%q was split into %q and %q was expected", c.input, args, c.expected)
+		}
+	}
+}
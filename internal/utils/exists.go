@@ -1,6 +1,10 @@
 package utils
 
-import "os"
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
 
 func FileExists(filename string) bool {
 	_, err := os.Stat(filename)
@@ -9,3 +13,13 @@ func FileExists(filename string) bool {
 	}
 	return err == nil
 }
+
+// ExecutableExists reports whether name is an existing file or, without a
+// path separator, can be found in PATH
+func ExecutableExists(name string) bool {
+	if strings.ContainsRune(name, os.PathSeparator) {
+		return FileExists(name)
+	}
+	_, err := exec.LookPath(name)
+	return err == nil
+}